@@ -0,0 +1,234 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// argSpec describes one positional argument of a command.
+type argSpec struct {
+	name     string
+	optional bool
+	// variadic arguments soak up every remaining positional argument and
+	// must come last.
+	variadic bool
+	// keepCase stops the argument from being lowercased, for file paths
+	// and other free text.
+	keepCase bool
+	// complete, if set, lists the values offered by tab completion.
+	complete func() []string
+}
+
+// flagSpec describes one --name=value option of a command.
+type flagSpec struct {
+	name        string
+	description string
+	// boolean flags are given as a bare --name.
+	boolean bool
+}
+
+// commandArgs holds the arguments a command was called with, along with
+// the config it runs against.
+type commandArgs struct {
+	positional []string
+	flags      map[string]string
+	config     *config
+}
+
+// arg returns the i-th positional argument, or "" if it was not given.
+func (a commandArgs) arg(i int) string {
+	if i < len(a.positional) {
+		return a.positional[i]
+	}
+	return ""
+}
+
+// rest returns the positional arguments from the i-th onwards.
+func (a commandArgs) rest(i int) []string {
+	if i < len(a.positional) {
+		return a.positional[i:]
+	}
+	return nil
+}
+
+// flag returns the value of the named flag and whether it was given.
+func (a commandArgs) flag(name string) (string, bool) {
+	value, ok := a.flags[name]
+	return value, ok
+}
+
+// splitCommands splits a -c script into commands on the semicolons that
+// are outside quotes, leaving the quotes for splitArgs to interpret.
+func splitCommands(script string) []string {
+	var commands []string
+	start := 0
+	var quote rune
+
+	runes := []rune(script)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		switch {
+		case r == '\\' && quote != '\'':
+			i++
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == ';':
+			commands = append(commands, string(runes[start:i]))
+			start = i + 1
+		}
+	}
+
+	return append(commands, string(runes[start:]))
+}
+
+// splitArgs splits a line into words on whitespace. Single or double
+// quotes group words containing spaces, and a backslash escapes the next
+// character.
+func splitArgs(line string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote rune
+
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		switch {
+		case r == '\\' && quote != '\'':
+			if i+1 < len(runes) {
+				i++
+				word.WriteRune(runes[i])
+			}
+			inWord = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
+
+// parseArgs matches words against the command's declared arguments and
+// flags. Everything after a bare "--" is positional.
+func parseArgs(command cliCommand, words []string) (commandArgs, error) {
+	args := commandArgs{flags: map[string]string{}}
+
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+
+		if word == "--" {
+			args.positional = append(args.positional, words[i+1:]...)
+			break
+		}
+
+		if !strings.HasPrefix(word, "--") {
+			args.positional = append(args.positional, word)
+			continue
+		}
+
+		name, value, hasValue := strings.Cut(strings.TrimPrefix(word, "--"), "=")
+		spec, ok := command.flag(name)
+		if !ok {
+			return args, fmt.Errorf("unknown flag --%s\nusage: %s", name, command.usage())
+		}
+
+		switch {
+		case spec.boolean && hasValue:
+			return args, fmt.Errorf("flag --%s does not take a value", name)
+		case spec.boolean:
+			value = "true"
+		case !hasValue && i+1 < len(words):
+			i++
+			value = words[i]
+		case !hasValue:
+			return args, fmt.Errorf("flag --%s needs a value\nusage: %s", name, command.usage())
+		}
+
+		args.flags[name] = strings.ToLower(value)
+	}
+
+	required, variadic := 0, false
+	for _, spec := range command.args {
+		if !spec.optional {
+			required++
+		}
+		variadic = variadic || spec.variadic
+	}
+	if len(args.positional) < required || (!variadic && len(args.positional) > len(command.args)) {
+		return args, errors.New("usage: " + command.usage())
+	}
+
+	for i := range args.positional {
+		spec := command.args[min(i, len(command.args)-1)]
+		if !spec.keepCase {
+			args.positional[i] = strings.ToLower(args.positional[i])
+		}
+	}
+
+	return args, nil
+}
+
+func (c cliCommand) flag(name string) (flagSpec, bool) {
+	for _, spec := range c.flags {
+		if spec.name == name {
+			return spec, true
+		}
+	}
+	return flagSpec{}, false
+}
+
+// usage returns a one-line synopsis such as
+// "catch <pokemon> [--ball=<ball>]".
+func (c cliCommand) usage() string {
+	parts := []string{c.name}
+
+	for _, spec := range c.args {
+		part := "<" + spec.name + ">"
+		if spec.variadic {
+			part += "..."
+		}
+		if spec.optional {
+			part = "[" + part + "]"
+		}
+		parts = append(parts, part)
+	}
+
+	for _, spec := range c.flags {
+		if spec.boolean {
+			parts = append(parts, "[--"+spec.name+"]")
+		} else {
+			parts = append(parts, "[--"+spec.name+"=<"+spec.name+">]")
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
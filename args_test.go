@@ -0,0 +1,113 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitArgs(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected []string
+	}{
+		{
+			input:    "  catch  pikachu  ",
+			expected: []string{"catch", "pikachu"},
+		},
+		{
+			input:    `save "My Pokedex.json"`,
+			expected: []string{"save", "My Pokedex.json"},
+		},
+		{
+			input:    `nickname pikachu 'Sparky Jr' --x=a\ b`,
+			expected: []string{"nickname", "pikachu", "Sparky Jr", "--x=a b"},
+		},
+		{
+			input:    `explore ""`,
+			expected: []string{"explore", ""},
+		},
+	}
+
+	for _, c := range cases {
+		actual, err := splitArgs(c.input)
+		if err != nil {
+			t.Errorf("splitArgs(%q): %v", c.input, err)
+			continue
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("splitArgs(%q) = %q, expected %q", c.input, actual, c.expected)
+		}
+	}
+
+	if _, err := splitArgs(`save "unterminated`); err == nil {
+		t.Errorf("expected an error for an unterminated quote")
+	}
+}
+
+func TestSplitCommands(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected []string
+	}{
+		{
+			input:    "help; pokedex",
+			expected: []string{"help", " pokedex"},
+		},
+		{
+			input:    `nickname 1 "a;b"; party`,
+			expected: []string{`nickname 1 "a;b"`, " party"},
+		},
+		{
+			input:    `save a\;b.json; nickname 1 'x;y'`,
+			expected: []string{`save a\;b.json`, ` nickname 1 'x;y'`},
+		},
+	}
+
+	for _, c := range cases {
+		if actual := splitCommands(c.input); !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("splitCommands(%q) = %q, expected %q", c.input, actual, c.expected)
+		}
+	}
+}
+
+func TestParseArgs(t *testing.T) {
+	command := cliCommand{
+		name: "test",
+		args: []argSpec{
+			{name: "pokemon"},
+			{name: "names", optional: true, variadic: true, keepCase: true},
+		},
+		flags: []flagSpec{
+			{name: "ball"},
+			{name: "verbose", boolean: true},
+		},
+	}
+
+	args, err := parseArgs(command, []string{"Pikachu", "--ball=Great", "Sparky", "--verbose", "Zap"})
+	if err != nil {
+		t.Fatalf("parseArgs: %v", err)
+	}
+	if args.arg(0) != "pikachu" {
+		t.Errorf("expected the pokemon argument to be lowercased, got %q", args.arg(0))
+	}
+	if !reflect.DeepEqual(args.rest(1), []string{"Sparky", "Zap"}) {
+		t.Errorf("expected variadic arguments to keep their case, got %q", args.rest(1))
+	}
+	if ball, _ := args.flag("ball"); ball != "great" {
+		t.Errorf("expected --ball=great, got %q", ball)
+	}
+	if _, ok := args.flag("verbose"); !ok {
+		t.Errorf("expected --verbose to be set")
+	}
+
+	if _, err := parseArgs(command, nil); err == nil {
+		t.Errorf("expected an error for a missing argument")
+	}
+	if _, err := parseArgs(command, []string{"pikachu", "--bogus"}); err == nil {
+		t.Errorf("expected an error for an unknown flag")
+	}
+
+	if usage := command.usage(); usage != "test <pokemon> [<names>...] [--ball=<ball>] [--verbose]" {
+		t.Errorf("unexpected usage: %q", usage)
+	}
+}
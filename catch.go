@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// pokeBall is a ball that can be thrown with catch.
+type pokeBall struct {
+	// item is the ball's PokeAPI item name, such as "great-ball".
+	item  string
+	name  string
+	bonus float64
+}
+
+var pokeBalls = map[string]pokeBall{
+	"poke-ball":   {item: "poke-ball", name: "Poke Ball", bonus: 1},
+	"great-ball":  {item: "great-ball", name: "Great Ball", bonus: 1.5},
+	"ultra-ball":  {item: "ultra-ball", name: "Ultra Ball", bonus: 2},
+	"master-ball": {item: "master-ball", name: "Master Ball", bonus: 255},
+}
+
+// withArticle prefixes name with "a" or "an", as in "an Ultra Ball".
+func withArticle(name string) string {
+	if name != "" && strings.ContainsRune("AEIOUaeiou", rune(name[0])) {
+		return "an " + name
+	}
+	return "a " + name
+}
+
+// statusBonuses are the catch rate modifiers for each status condition
+// the target can have.
+var statusBonuses = map[string]float64{
+	"none":      1,
+	"sleep":     2,
+	"freeze":    2,
+	"paralysis": 1.5,
+	"poison":    1.5,
+	"burn":      1.5,
+}
+
+// throw describes a ball thrown at a wild Pokemon.
+type throw struct {
+	ball pokeBall
+	// hp is the target's remaining HP, as a percentage of its maximum.
+	hp     int
+	status string
+}
+
+// parseThrow reads the --ball, --hp and --status flags of catch. Balls may
+// be named in full, "great-ball", or by their first word, "great".
+func parseThrow(args commandArgs) (throw, error) {
+	t := throw{ball: pokeBalls["poke-ball"], hp: 100, status: "none"}
+
+	if name, ok := args.flag("ball"); ok {
+		if !strings.HasSuffix(name, "-ball") {
+			name += "-ball"
+		}
+		ball, ok := pokeBalls[name]
+		if !ok {
+			return t, fmt.Errorf("unknown ball %q, expected one of %s", name, strings.Join(sortedKeys(pokeBalls), ", "))
+		}
+		t.ball = ball
+	}
+
+	if value, ok := args.flag("hp"); ok {
+		hp, err := strconv.Atoi(strings.TrimSuffix(value, "%"))
+		if err != nil || hp < 1 || hp > 100 {
+			return t, fmt.Errorf("--hp must be a percentage from 1 to 100, got %q", value)
+		}
+		t.hp = hp
+	}
+
+	if status, ok := args.flag("status"); ok {
+		if _, ok := statusBonuses[status]; !ok {
+			return t, fmt.Errorf("unknown status %q, expected one of %s", status, strings.Join(sortedKeys(statusBonuses), ", "))
+		}
+		t.status = status
+	}
+
+	return t, nil
+}
+
+// catchValue is the modified catch rate "a" of the generation III and IV
+// games: the species' capture rate scaled up as the target's HP drops,
+// and by the ball and status bonuses. At 255 or more the catch is certain.
+func (t throw) catchValue(captureRate int) float64 {
+	const maxHP = 100
+	hpFactor := float64(3*maxHP-2*t.hp) / float64(3*maxHP)
+	return float64(captureRate) * hpFactor * t.ball.bonus * statusBonuses[t.status]
+}
+
+// shakeChance is the chance, out of 65536, of the ball passing one shake
+// check for a catch value of a.
+func shakeChance(a float64) float64 {
+	if a <= 0 {
+		return 0
+	}
+	return 1048560 / math.Sqrt(math.Sqrt(16711680/a))
+}
+
+// attempt throws the ball at a Pokemon with the given capture rate. The
+// ball makes up to four shake checks, catching the Pokemon if all of them
+// pass; shakes is how many passed, of which the player sees at most three.
+func (t throw) attempt(rng *rand.Rand, captureRate int) (shakes int, caught bool) {
+	a := t.catchValue(captureRate)
+	if a >= 255 {
+		return 3, true
+	}
+
+	b := shakeChance(a)
+	for shakes = 0; shakes < 4; shakes++ {
+		if float64(rng.Intn(65536)) >= b {
+			return min(shakes, 3), false
+		}
+	}
+	return 3, true
+}
+
+// shakeMessage narrates a throw, such as "1... 2... 3... caught!".
+func shakeMessage(pokemon string, shakes int, caught bool) string {
+	var b strings.Builder
+	for i := 1; i <= shakes; i++ {
+		fmt.Fprintf(&b, "%d... ", i)
+	}
+	if caught {
+		b.WriteString("caught!")
+	} else {
+		b.WriteString(pokemon + " broke free!")
+	}
+	return b.String()
+}
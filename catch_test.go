@@ -0,0 +1,100 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseThrow(t *testing.T) {
+	command := commands["catch"]
+
+	parse := func(line string) (throw, error) {
+		words, err := splitArgs(line)
+		if err != nil {
+			t.Fatal(err)
+		}
+		args, err := parseArgs(command, words)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return parseThrow(args)
+	}
+
+	th, err := parse("pikachu")
+	if err != nil || th.ball.item != "poke-ball" || th.hp != 100 || th.status != "none" {
+		t.Errorf("expected a Poke Ball at full health, got %+v (%v)", th, err)
+	}
+
+	th, err = parse("pikachu --ball=Great --hp=25% --status=sleep")
+	if err != nil || th.ball.item != "great-ball" || th.hp != 25 || th.status != "sleep" {
+		t.Errorf("expected a Great Ball at 25%% asleep, got %+v (%v)", th, err)
+	}
+
+	for _, line := range []string{"pikachu --ball=net", "pikachu --hp=0", "pikachu --hp=lots", "pikachu --status=confused"} {
+		if _, err := parse(line); err == nil {
+			t.Errorf("%s: expected an error", line)
+		}
+	}
+}
+
+func TestCatchValue(t *testing.T) {
+	cases := []struct {
+		throw       throw
+		captureRate int
+		expected    float64
+	}{
+		{throw{ball: pokeBalls["poke-ball"], hp: 100, status: "none"}, 45, 15},
+		{throw{ball: pokeBalls["great-ball"], hp: 100, status: "none"}, 45, 22.5},
+		{throw{ball: pokeBalls["ultra-ball"], hp: 100, status: "sleep"}, 45, 60},
+		{throw{ball: pokeBalls["poke-ball"], hp: 1, status: "paralysis"}, 190, 190 * 298.0 / 300 * 1.5},
+		{throw{ball: pokeBalls["master-ball"], hp: 100, status: "none"}, 3, 255},
+	}
+
+	for _, c := range cases {
+		if actual := c.throw.catchValue(c.captureRate); math.Abs(actual-c.expected) > 1e-9 {
+			t.Errorf("%+v at rate %d: expected %v, got %v", c.throw, c.captureRate, c.expected, actual)
+		}
+	}
+}
+
+func TestShakeChance(t *testing.T) {
+	if actual := shakeChance(255); math.Abs(actual-65535) > 1e-6 {
+		t.Errorf("expected a near-certain shake at 255, got %v", actual)
+	}
+	if actual := shakeChance(0); actual != 0 {
+		t.Errorf("expected no chance at 0, got %v", actual)
+	}
+	if shakeChance(15) >= shakeChance(60) {
+		t.Errorf("expected higher catch values to shake more")
+	}
+}
+
+func TestMasterBallAlwaysCatches(t *testing.T) {
+	th := throw{ball: pokeBalls["master-ball"], hp: 100, status: "none"}
+	for i := 0; i < 100; i++ {
+		if shakes, caught := th.attempt(c.rand, 3); !caught || shakes != 3 {
+			t.Fatalf("expected a Master Ball to always catch, got %d shakes", shakes)
+		}
+	}
+}
+
+func TestShakeMessage(t *testing.T) {
+	if actual := shakeMessage("pikachu", 3, true); actual != "1... 2... 3... caught!" {
+		t.Errorf("unexpected message %q", actual)
+	}
+	if actual := shakeMessage("pikachu", 2, false); actual != "1... 2... pikachu broke free!" {
+		t.Errorf("unexpected message %q", actual)
+	}
+	if actual := shakeMessage("pikachu", 0, false); actual != "pikachu broke free!" {
+		t.Errorf("unexpected message %q", actual)
+	}
+}
+
+func TestWithArticle(t *testing.T) {
+	if actual := withArticle("Ultra Ball"); actual != "an Ultra Ball" {
+		t.Errorf("unexpected %q", actual)
+	}
+	if actual := withArticle("Poke Ball"); actual != "a Poke Ball" {
+		t.Errorf("unexpected %q", actual)
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"sort"
+	"strings"
+)
+
+// Names the REPL has come across this session, offered by tab completion.
+var (
+	seenLocationAreas map[string]bool = map[string]bool{}
+	seenPokemon       map[string]bool = map[string]bool{}
+)
+
+func commandNames() []string {
+	return sortedKeys(commands)
+}
+
+func locationAreaNames() []string {
+	return sortedKeys(seenLocationAreas)
+}
+
+func pokemonNames() []string {
+	return sortedKeys(seenPokemon)
+}
+
+func caughtNames() []string {
+	return bag.refs()
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// complete is the terminal's tab completion callback. It completes the
+// word before the cursor: a command name in first position, otherwise
+// whatever the command's argument at that position offers.
+func complete(line string, pos int, key rune) (string, int, bool) {
+	if key != '\t' {
+		return "", 0, false
+	}
+
+	before := line[:pos]
+	start := strings.LastIndexAny(before, " \t") + 1
+	partial := before[start:]
+	previous := strings.Fields(before[:start])
+
+	candidates := completions(previous)
+
+	var matches []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, strings.ToLower(partial)) {
+			matches = append(matches, candidate)
+		}
+	}
+	if len(matches) == 0 {
+		return "", 0, false
+	}
+
+	completed := commonPrefix(matches)
+	if len(matches) == 1 {
+		completed += " "
+	}
+	if len(completed) <= len(partial) {
+		return "", 0, false
+	}
+
+	newLine := before[:start] + completed + line[pos:]
+	return newLine, start + len(completed), true
+}
+
+// completions returns the candidates for the word following previous.
+func completions(previous []string) []string {
+	if len(previous) == 0 {
+		return commandNames()
+	}
+
+	command, ok := commands[strings.ToLower(previous[0])]
+	if !ok || len(command.args) == 0 {
+		return nil
+	}
+
+	position := 0
+	for _, word := range previous[1:] {
+		if !strings.HasPrefix(word, "--") {
+			position++
+		}
+	}
+
+	spec := command.args[min(position, len(command.args)-1)]
+	if position >= len(command.args) && !spec.variadic {
+		return nil
+	}
+	if spec.complete == nil {
+		return nil
+	}
+	return spec.complete()
+}
+
+func commonPrefix(words []string) string {
+	prefix := words[0]
+	for _, word := range words[1:] {
+		for !strings.HasPrefix(word, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
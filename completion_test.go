@@ -0,0 +1,53 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestComplete(t *testing.T) {
+	seenLocationAreas = map[string]bool{"canalave-city-area": true, "eterna-city-area": true}
+	bag = storageOf("pikachu", "pidgey")
+
+	cases := []struct {
+		line     string
+		expected string
+	}{
+		{line: "exp", expected: "explore "},
+		{line: "explore can", expected: "explore canalave-city-area "},
+		{line: "inspect pikachu pid", expected: "inspect pikachu pidgey "},
+		{line: "inspect p", expected: "inspect pi"},
+		{line: "save fo", expected: ""},
+	}
+
+	for _, c := range cases {
+		actual, pos, ok := complete(c.line, len(c.line), '\t')
+		if c.expected == "" {
+			if ok {
+				t.Errorf("complete(%q) = %q, expected no completion", c.line, actual)
+			}
+			continue
+		}
+		if !ok || actual != c.expected || pos != len(c.expected) {
+			t.Errorf("complete(%q) = %q, expected %q", c.line, actual, c.expected)
+		}
+	}
+}
+
+func TestHistoryFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history")
+
+	history := loadHistory(path, 2)
+	history.Add("map")
+	history.Add("map")
+	history.Add("explore canalave-city-area")
+	history.Add("catch tentacool")
+
+	reloaded := loadHistory(path, 2)
+	if reloaded.Len() != 2 {
+		t.Fatalf("expected 2 entries, got %d", reloaded.Len())
+	}
+	if reloaded.At(0) != "catch tentacool" || reloaded.At(1) != "explore canalave-city-area" {
+		t.Errorf("expected the most recent entries, newest first, got %q and %q", reloaded.At(0), reloaded.At(1))
+	}
+}
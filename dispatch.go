@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// errOutput is where errors from commands are reported.
+var errOutput io.Writer = os.Stderr
+
+// reportError prints err in the REPL's standard format.
+func reportError(err error) {
+	fmt.Fprintf(errOutput, "Error: %v\n", err)
+}
+
+// dispatch runs command with args. A panic inside the command is turned
+// into an error so that one broken command can't end the session.
+func dispatch(command cliCommand, args commandArgs) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%s failed unexpectedly: %v", command.name, r)
+		}
+	}()
+
+	args.config = command.config
+	return command.callback(args)
+}
+
+// unknownCommandError explains that name is not a command, suggesting the
+// closest one if name looks like a typo.
+func unknownCommandError(name string) error {
+	if suggestion, ok := closestCommand(name); ok {
+		return fmt.Errorf("unknown command %q, did you mean %q?", name, suggestion)
+	}
+	return fmt.Errorf("unknown command %q, type help to list commands", name)
+}
+
+// closestCommand returns the command name with the smallest edit distance
+// to name, if it is close enough to be a likely typo.
+func closestCommand(name string) (string, bool) {
+	best, bestDistance := "", -1
+	for _, candidate := range commandNames() {
+		distance := editDistance(name, candidate)
+		if bestDistance < 0 || distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+
+	limit := max(1, len(name)/3)
+	return best, bestDistance >= 0 && bestDistance <= limit
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
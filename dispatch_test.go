@@ -0,0 +1,57 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDispatchRecoversPanics(t *testing.T) {
+	command := cliCommand{
+		name: "broken",
+		callback: func(args commandArgs) error {
+			var bag map[string]int
+			bag["pikachu"] = 1
+			return nil
+		},
+	}
+
+	err := dispatch(command, commandArgs{})
+	if err == nil || !strings.HasPrefix(err.Error(), "broken failed unexpectedly") {
+		t.Errorf("expected the panic to be returned as an error, got %v", err)
+	}
+}
+
+func TestUnknownCommandSuggestion(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{input: "cath", expected: `unknown command "cath", did you mean "catch"?`},
+		{input: "expolre", expected: `unknown command "expolre", did you mean "explore"?`},
+		{input: "xyzzy", expected: `unknown command "xyzzy", type help to list commands`},
+	}
+
+	for _, c := range cases {
+		err := runCommand(c.input)
+		if err == nil || err.Error() != c.expected {
+			t.Errorf("runCommand(%q) = %v, expected %q", c.input, err, c.expected)
+		}
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	cases := []struct {
+		a, b     string
+		expected int
+	}{
+		{a: "", b: "map", expected: 3},
+		{a: "mapb", b: "map", expected: 1},
+		{a: "kitten", b: "sitting", expected: 3},
+	}
+
+	for _, c := range cases {
+		if actual := editDistance(c.a, c.b); actual != c.expected {
+			t.Errorf("editDistance(%q, %q) = %d, expected %d", c.a, c.b, actual, c.expected)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/chandanbsd/pokedex/internal/model"
+)
+
+// encounterFilter narrows the encounters shown for an area. Zero values
+// match everything.
+type encounterFilter struct {
+	version   string
+	method    string
+	minChance int
+}
+
+// encounterFilterFlags are the flags read by parseEncounterFilter.
+var encounterFilterFlags = []flagSpec{
+	{name: "version", description: "only encounters in this game version, such as red or diamond"},
+	{name: "method", description: "only encounters with this method, such as walk or surf"},
+	{name: "min-chance", description: "only encounters at least this likely, in percent"},
+}
+
+// parseEncounterFilter reads the --version, --method and --min-chance
+// flags.
+func parseEncounterFilter(args commandArgs) (encounterFilter, error) {
+	var filter encounterFilter
+	filter.version, _ = args.flag("version")
+	filter.method, _ = args.flag("method")
+
+	if value, ok := args.flag("min-chance"); ok {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return filter, fmt.Errorf("--min-chance must be a percentage, got %q", value)
+		}
+		filter.minChance = n
+	}
+	return filter, nil
+}
+
+func (f encounterFilter) matchesVersion(version string) bool {
+	return f.version == "" || f.version == version
+}
+
+func (f encounterFilter) matchesMethod(method string) bool {
+	return f.method == "" || f.method == method
+}
+
+// encounterRow sums up how a Pokemon is met in an area with one method in
+// one version: the combined chance of every encounter slot and the range
+// of levels across them.
+type encounterRow struct {
+	pokemon  string
+	area     string
+	version  string
+	method   string
+	chance   int
+	minLevel int
+	maxLevel int
+}
+
+func (r encounterRow) levels() string {
+	if r.minLevel == r.maxLevel {
+		return strconv.Itoa(r.minLevel)
+	}
+	return fmt.Sprintf("%d-%d", r.minLevel, r.maxLevel)
+}
+
+// encounterRows merges an area's encounter slots into one row per
+// Pokemon, version and method, keeping the rows that pass filter. Rows
+// stay in the order PokeAPI lists the Pokemon and versions.
+func encounterRows(area model.LocationArea, filter encounterFilter) []encounterRow {
+	var rows []encounterRow
+	for _, encounter := range area.PokemonEncounters {
+		rows = appendEncounterRows(rows, encounter.Pokemon.Name, area.Name, encounter.VersionDetails, filter)
+	}
+	return rows
+}
+
+// appendEncounterRows merges the encounter slots of one Pokemon in one
+// area into rows, as encounterRows does.
+func appendEncounterRows(rows []encounterRow, pokemon, area string, versions []model.VersionEncounterDetail, filter encounterFilter) []encounterRow {
+	start := len(rows)
+	for _, details := range versions {
+		if !filter.matchesVersion(details.Version.Name) {
+			continue
+		}
+
+		first := len(rows)
+		for _, e := range details.EncounterDetails {
+			if !filter.matchesMethod(e.Method.Name) {
+				continue
+			}
+
+			i := slices.IndexFunc(rows[first:], func(row encounterRow) bool {
+				return row.method == e.Method.Name
+			})
+			if i < 0 {
+				rows = append(rows, encounterRow{
+					pokemon:  pokemon,
+					area:     area,
+					version:  details.Version.Name,
+					method:   e.Method.Name,
+					minLevel: e.MinLevel,
+					maxLevel: e.MaxLevel,
+				})
+				i = len(rows) - 1 - first
+			}
+
+			row := &rows[first+i]
+			row.chance += e.Chance
+			row.minLevel = min(row.minLevel, e.MinLevel)
+			row.maxLevel = max(row.maxLevel, e.MaxLevel)
+		}
+	}
+
+	kept := slices.DeleteFunc(rows[start:], func(row encounterRow) bool {
+		return row.chance < filter.minChance
+	})
+	return rows[:start+len(kept)]
+}
+
+// printEncounterTable prints rows as a table.
+func printEncounterTable(w io.Writer, rows []encounterRow) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "POKEMON\tVERSION\tMETHOD\tCHANCE\tLEVELS")
+	for _, row := range rows {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d%%\t%s\n", row.pokemon, row.version, row.method, row.chance, row.levels())
+	}
+	tw.Flush()
+}
+
+// printEncounterRates summarises how often each encounter method triggers
+// an encounter in the area, for the versions passing filter. Versions
+// sharing a rate are listed together.
+func printEncounterRates(w io.Writer, area model.LocationArea, filter encounterFilter) {
+	var lines []string
+	for _, rate := range area.EncounterMethodRates {
+		if !filter.matchesMethod(rate.EncounterMethod.Name) {
+			continue
+		}
+
+		var rates []int
+		byRate := map[int][]string{}
+		for _, details := range rate.VersionDetails {
+			if !filter.matchesVersion(details.Version.Name) {
+				continue
+			}
+			if _, ok := byRate[details.Rate]; !ok {
+				rates = append(rates, details.Rate)
+			}
+			byRate[details.Rate] = append(byRate[details.Rate], details.Version.Name)
+		}
+
+		for _, r := range rates {
+			lines = append(lines, fmt.Sprintf("  %s: %d%% (%s)", rate.EncounterMethod.Name, r, strings.Join(byRate[r], ", ")))
+		}
+	}
+
+	if len(lines) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "Encounter rates:")
+	for _, line := range lines {
+		fmt.Fprintln(w, line)
+	}
+}
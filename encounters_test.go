@@ -0,0 +1,58 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chandanbsd/pokedex/internal/model"
+)
+
+func TestEncounterRows(t *testing.T) {
+	area := loadFixture[model.LocationArea](t, "fixtures/pokeapi/location-area/canalave-city-area.json")
+
+	rows := encounterRows(area, encounterFilter{version: "diamond"})
+	if len(rows) != 5 {
+		t.Fatalf("expected 5 rows for diamond, got %+v", rows)
+	}
+	magikarp := rows[2]
+	if magikarp.pokemon != "magikarp" || magikarp.method != "old-rod" || magikarp.chance != 100 || magikarp.levels() != "3-15" {
+		t.Errorf("expected old rod magikarp slots to be merged, got %+v", magikarp)
+	}
+
+	rows = encounterRows(area, encounterFilter{method: "surf", minChance: 50})
+	for _, row := range rows {
+		if row.pokemon != "tentacool" || row.method != "surf" {
+			t.Errorf("expected only tentacool surfing, got %+v", row)
+		}
+	}
+	if len(rows) != 3 {
+		t.Errorf("expected a row per version, got %d", len(rows))
+	}
+}
+
+func TestPrintEncounterRates(t *testing.T) {
+	area := loadFixture[model.LocationArea](t, "fixtures/pokeapi/location-area/canalave-city-area.json")
+
+	var out strings.Builder
+	printEncounterRates(&out, area, encounterFilter{method: "surf", version: "pearl"})
+	if expected := "Encounter rates:\n  surf: 10% (pearl)\n"; out.String() != expected {
+		t.Errorf("expected %q, got %q", expected, out.String())
+	}
+
+	out.Reset()
+	printEncounterRates(&out, area, encounterFilter{method: "walk"})
+	if out.String() != "" {
+		t.Errorf("expected nothing for a method not used in the area, got %q", out.String())
+	}
+}
+
+func TestExploreFilters(t *testing.T) {
+	useCassette(t, "testdata/session.json")
+
+	if err := runCommand("explore canalave-city-area --details --version=diamond --min-chance=50"); err != nil {
+		t.Errorf("explore: %v", err)
+	}
+	if err := runCommand("explore canalave-city-area --min-chance=lots"); err == nil {
+		t.Errorf("expected a bad --min-chance to fail")
+	}
+}
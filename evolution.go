@@ -0,0 +1,168 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/chandanbsd/pokedex/internal/model"
+	"github.com/chandanbsd/pokedex/internal/pokeapi"
+)
+
+// commandEvolution prints the evolution chain of a Pokemon, following its
+// species to the chain so every branch is shown, not just its own line.
+func commandEvolution(args commandArgs) error {
+	pokemonName := args.arg(0)
+
+	pokemon, err := client.GetPokemon(pokemonName)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return fmt.Errorf("no Pokemon named %s", pokemonName)
+	}
+	if err != nil {
+		return apiError(err)
+	}
+
+	species, err := pokeapi.Fetch[model.PokemonSpecies](client, pokemon.Species.URL)
+	if err != nil {
+		return apiError(err)
+	}
+	if species.EvolutionChain == nil {
+		fmt.Printf("%s does not evolve\n", pokemonName)
+		return nil
+	}
+
+	chain, err := pokeapi.Fetch[model.EvolutionChain](client, species.EvolutionChain.URL)
+	if err != nil {
+		return apiError(err)
+	}
+
+	printChain(os.Stdout, chain.Chain)
+	return nil
+}
+
+// printChain draws the evolution tree rooted at link, one species per line
+// with the conditions for evolving into it:
+//
+//	eevee
+//	├─ vaporeon (use water-stone)
+//	└─ espeon (level up, friendship 160, day)
+func printChain(w io.Writer, link model.ChainLink) {
+	fmt.Fprintln(w, chainLinkLabel(link))
+	printEvolutions(w, link.EvolvesTo, "")
+}
+
+func printEvolutions(w io.Writer, links []model.ChainLink, indent string) {
+	for i, link := range links {
+		branch, next := "├─ ", "│  "
+		if i == len(links)-1 {
+			branch, next = "└─ ", "   "
+		}
+
+		fmt.Fprintf(w, "%s%s%s", indent, branch, chainLinkLabel(link))
+		if len(link.EvolutionDetails) > 0 {
+			conditions := make([]string, len(link.EvolutionDetails))
+			for j, detail := range link.EvolutionDetails {
+				conditions[j] = describeEvolution(detail)
+			}
+			fmt.Fprintf(w, " (%s)", strings.Join(conditions, " or "))
+		}
+		fmt.Fprintln(w)
+
+		printEvolutions(w, link.EvolvesTo, indent+next)
+	}
+}
+
+func chainLinkLabel(link model.ChainLink) string {
+	seenPokemon[link.Species.Name] = true
+	if link.IsBaby {
+		return link.Species.Name + " [baby]"
+	}
+	return link.Species.Name
+}
+
+// describeEvolution summarises what triggers an evolution, such as
+// "level 16", "use thunder-stone" or "level up, friendship 220".
+func describeEvolution(detail model.EvolutionDetail) string {
+	var parts []string
+
+	switch detail.Trigger.Name {
+	case "level-up":
+		if detail.MinLevel != nil {
+			parts = append(parts, fmt.Sprintf("level %d", *detail.MinLevel))
+		} else {
+			parts = append(parts, "level up")
+		}
+	case "use-item":
+		if detail.Item != nil {
+			parts = append(parts, "use "+detail.Item.Name)
+		} else {
+			parts = append(parts, "use item")
+		}
+	case "trade":
+		parts = append(parts, "trade")
+		if detail.TradeSpecies != nil {
+			parts = append(parts, "for "+detail.TradeSpecies.Name)
+		}
+	default:
+		parts = append(parts, strings.ReplaceAll(detail.Trigger.Name, "-", " "))
+	}
+
+	if detail.HeldItem != nil {
+		parts = append(parts, "holding "+detail.HeldItem.Name)
+	}
+	if detail.KnownMove != nil {
+		parts = append(parts, "knowing "+detail.KnownMove.Name)
+	}
+	if detail.KnownMoveType != nil {
+		parts = append(parts, "knowing a "+detail.KnownMoveType.Name+" move")
+	}
+	if detail.Location != nil {
+		parts = append(parts, "at "+detail.Location.Name)
+	}
+	if detail.MinHappiness != nil {
+		parts = append(parts, fmt.Sprintf("friendship %d", *detail.MinHappiness))
+	}
+	if detail.MinAffection != nil {
+		parts = append(parts, fmt.Sprintf("affection %d", *detail.MinAffection))
+	}
+	if detail.MinBeauty != nil {
+		parts = append(parts, fmt.Sprintf("beauty %d", *detail.MinBeauty))
+	}
+	if detail.TimeOfDay != "" {
+		parts = append(parts, detail.TimeOfDay)
+	}
+	if detail.NeedsOverworldRain {
+		parts = append(parts, "raining")
+	}
+	if detail.PartySpecies != nil {
+		parts = append(parts, "with "+detail.PartySpecies.Name+" in party")
+	}
+	if detail.PartyType != nil {
+		parts = append(parts, "with a "+detail.PartyType.Name+" type in party")
+	}
+	if detail.Gender != nil {
+		switch *detail.Gender {
+		case 1:
+			parts = append(parts, "female")
+		case 2:
+			parts = append(parts, "male")
+		}
+	}
+	if detail.RelativePhysicalStats != nil {
+		switch *detail.RelativePhysicalStats {
+		case 1:
+			parts = append(parts, "attack > defense")
+		case -1:
+			parts = append(parts, "attack < defense")
+		case 0:
+			parts = append(parts, "attack = defense")
+		}
+	}
+	if detail.TurnUpsideDown {
+		parts = append(parts, "upside down")
+	}
+
+	return strings.Join(parts, ", ")
+}
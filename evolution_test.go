@@ -0,0 +1,65 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chandanbsd/pokedex/internal/model"
+)
+
+func TestPrintChain(t *testing.T) {
+	cases := []struct {
+		path     string
+		expected string
+	}{
+		{
+			path: "fixtures/pokeapi/evolution-chain/10.json",
+			expected: `pichu [baby]
+└─ pikachu (level up, friendship 220)
+   └─ raichu (use thunder-stone)
+`,
+		},
+		{
+			path: "fixtures/pokeapi/evolution-chain/67.json",
+			expected: `eevee
+├─ vaporeon (use water-stone)
+├─ jolteon (use thunder-stone)
+├─ flareon (use fire-stone)
+├─ espeon (level up, friendship 160, day)
+├─ umbreon (level up, friendship 160, night)
+├─ leafeon (use leaf-stone)
+├─ glaceon (use ice-stone)
+└─ sylveon (level up, knowing a fairy move, affection 2)
+`,
+		},
+		{
+			path: "fixtures/pokeapi/evolution-chain/31.json",
+			expected: `geodude
+└─ graveler (level 25)
+   └─ golem (trade)
+`,
+		},
+	}
+
+	for _, c := range cases {
+		var out strings.Builder
+		printChain(&out, loadFixture[model.EvolutionChain](t, c.path).Chain)
+		if out.String() != c.expected {
+			t.Errorf("%s: expected\n%s\ngot\n%s", c.path, c.expected, out.String())
+		}
+	}
+}
+
+func TestDescribeEvolution(t *testing.T) {
+	level, gender := 30, 1
+	detail := model.EvolutionDetail{
+		Trigger:  model.NamedAPIResource[model.EvolutionTrigger]{Name: "level-up"},
+		MinLevel: &level,
+		Gender:   &gender,
+		HeldItem: &model.NamedAPIResource[model.Item]{Name: "razor-fang"},
+	}
+
+	if actual, expected := describeEvolution(detail), "level 30, holding razor-fang, female"; actual != expected {
+		t.Errorf("expected %q, got %q", expected, actual)
+	}
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+// loadFixture decodes the recorded PokeAPI response at path, such as
+// "fixtures/pokeapi/pokemon/pikachu.json".
+func loadFixture[T any](t *testing.T, path string) T {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		t.Fatalf("decoding %s: %v", path, err)
+	}
+	return v
+}
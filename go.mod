@@ -1,3 +1,7 @@
 module github.com/chandanbsd/pokedex
 
 go 1.24.4
+
+require golang.org/x/term v0.36.0
+
+require golang.org/x/sys v0.37.0 // indirect
@@ -0,0 +1,168 @@
+// Package cassette records HTTP exchanges to a file and replays them
+// later, so PokeAPI traffic can be reproduced without network access.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ErrUnrecorded is returned when replaying a request the cassette has no
+// response for.
+var ErrUnrecorded = errors.New("request not recorded in cassette")
+
+// Interaction is one recorded request and its response.
+type Interaction struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body"`
+}
+
+// Cassette is the on-disk format: every interaction in the order it
+// happened.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Load reads the cassette at path.
+func Load(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		return nil, fmt.Errorf("reading cassette %s: %w", path, err)
+	}
+	return &cassette, nil
+}
+
+// Save writes the cassette to path, replacing it atomically.
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Recorder is an http.RoundTripper that forwards requests to next and
+// appends every exchange to the cassette at path, saving after each one.
+type Recorder struct {
+	path     string
+	next     http.RoundTripper
+	mutex    sync.Mutex
+	cassette Cassette
+}
+
+// NewRecorder returns a recorder writing to path. If next is nil,
+// http.DefaultTransport is used.
+func NewRecorder(path string, next http.RoundTripper) *Recorder {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Recorder{path: path, next: next}
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Status: res.StatusCode,
+		Header: res.Header,
+		Body:   string(body),
+	})
+	if err := r.cassette.Save(r.path); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Replayer is an http.RoundTripper that answers requests from a cassette
+// without touching the network. Repeated requests for the same URL get
+// the recorded responses in order, then the last one again.
+type Replayer struct {
+	mutex  sync.Mutex
+	byKey  map[string][]Interaction
+	served map[string]int
+}
+
+// NewReplayer returns a replayer serving the interactions in cassette.
+func NewReplayer(cassette *Cassette) *Replayer {
+	r := &Replayer{
+		byKey:  map[string][]Interaction{},
+		served: map[string]int{},
+	}
+	for _, interaction := range cassette.Interactions {
+		key := interaction.Method + " " + interaction.URL
+		r.byKey[key] = append(r.byKey[key], interaction)
+	}
+	return r
+}
+
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := req.Method + " " + req.URL.String()
+
+	r.mutex.Lock()
+	interactions, ok := r.byKey[key]
+	if !ok {
+		r.mutex.Unlock()
+		return nil, fmt.Errorf("%w: %s", ErrUnrecorded, key)
+	}
+	i := min(r.served[key], len(interactions)-1)
+	r.served[key]++
+	r.mutex.Unlock()
+
+	interaction := interactions[i]
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.Status, http.StatusText(interaction.Status)),
+		StatusCode:    interaction.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        interaction.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader([]byte(interaction.Body))),
+		ContentLength: int64(len(interaction.Body)),
+		Request:       req,
+	}, nil
+}
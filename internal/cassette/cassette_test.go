@@ -0,0 +1,48 @@
+package cassette
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"name": "pikachu"}`))
+	}))
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	recording := &http.Client{Transport: NewRecorder(path, nil)}
+	res, err := recording.Get(server.URL + "/pokemon/pikachu")
+	if err != nil {
+		t.Fatalf("recording: %v", err)
+	}
+	res.Body.Close()
+	server.Close()
+
+	cassette, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	replaying := &http.Client{Transport: NewReplayer(cassette)}
+
+	res, err = replaying.Get(server.URL + "/pokemon/pikachu")
+	if err != nil {
+		t.Fatalf("replaying: %v", err)
+	}
+	body, _ := io.ReadAll(res.Body)
+	res.Body.Close()
+
+	if string(body) != `{"name": "pikachu"}` || res.Header.Get("ETag") != `"v1"` {
+		t.Errorf("expected the recorded response, got %q", body)
+	}
+
+	_, err = replaying.Get(server.URL + "/pokemon/pikachuu")
+	if !errors.Is(err, ErrUnrecorded) {
+		t.Errorf("expected ErrUnrecorded, got %v", err)
+	}
+}
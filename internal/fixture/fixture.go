@@ -0,0 +1,203 @@
+// Package fixture serves recorded PokeAPI responses from a directory, so
+// the REPL can run without network access.
+package fixture
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/chandanbsd/pokedex/internal/pokeapi"
+)
+
+// PathPrefix is where the handler mounts the API, mirroring PokeAPI.
+const PathPrefix = "/api/v2/"
+
+const defaultLimit = 20
+
+// Handler serves the JSON files under its directory the way PokeAPI
+// serves resources: dir/pokemon/pikachu.json answers
+// /api/v2/pokemon/pikachu, and may also be requested by the "id" it
+// contains. Requesting a directory such as /api/v2/location-area/ returns
+// a paged list of the resources in it, honouring offset and limit.
+//
+// URLs pointing at the public PokeAPI inside the recorded files are
+// rewritten to point back at the handler.
+type Handler struct {
+	dir string
+}
+
+// NewHandler returns a handler serving the fixtures under dir.
+func NewHandler(dir string) *Handler {
+	return &Handler{dir: dir}
+}
+
+type resourceList struct {
+	Count    int                `json:"count"`
+	Next     *string            `json:"next"`
+	Previous *string            `json:"previous"`
+	Results  []namedAPIResource `json:"results"`
+}
+
+type namedAPIResource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+type resource struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	path string
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.URL.Path, PathPrefix) {
+		http.NotFound(w, r)
+		return
+	}
+
+	baseURL := "http://" + r.Host + PathPrefix
+	name := strings.Trim(strings.TrimPrefix(r.URL.Path, PathPrefix), "/")
+
+	if strings.Contains(name, "..") {
+		http.NotFound(w, r)
+		return
+	}
+
+	if path, ok := h.find(name); ok {
+		h.serveFile(w, path, baseURL)
+		return
+	}
+
+	if info, err := os.Stat(filepath.Join(h.dir, name)); err == nil && info.IsDir() && name != "" {
+		h.serveList(w, r, name, baseURL)
+		return
+	}
+
+	http.NotFound(w, r)
+}
+
+// find resolves name, such as "pokemon/pikachu" or "pokemon/25", to a
+// fixture file.
+func (h *Handler) find(name string) (string, bool) {
+	if name == "" {
+		return "", false
+	}
+
+	path := filepath.Join(h.dir, filepath.FromSlash(name)+".json")
+	if _, err := os.Stat(path); err == nil {
+		return path, true
+	}
+
+	dir, last := filepath.Split(filepath.FromSlash(name))
+	id, err := strconv.Atoi(last)
+	if err != nil {
+		return "", false
+	}
+
+	resources, err := h.resources(filepath.Join(h.dir, dir))
+	if err != nil {
+		return "", false
+	}
+	for _, res := range resources {
+		if res.ID == id {
+			return res.path, true
+		}
+	}
+	return "", false
+}
+
+// resources reads the id and name of every fixture in dir, ordered by id
+// the way PokeAPI orders its lists.
+func (h *Handler) resources(dir string) ([]resource, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []resource
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var res resource
+		if err := json.Unmarshal(data, &res); err != nil {
+			continue
+		}
+		if res.Name == "" {
+			res.Name = strings.TrimSuffix(filepath.Base(path), ".json")
+		}
+		res.path = path
+		resources = append(resources, res)
+	}
+
+	sort.Slice(resources, func(i, j int) bool {
+		if resources[i].ID != resources[j].ID {
+			return resources[i].ID < resources[j].ID
+		}
+		return resources[i].Name < resources[j].Name
+	})
+	return resources, nil
+}
+
+func (h *Handler) serveFile(w http.ResponseWriter, path, baseURL string) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data = bytes.ReplaceAll(data, []byte(pokeapi.DefaultBaseURL), []byte(baseURL))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+func (h *Handler) serveList(w http.ResponseWriter, r *http.Request, name, baseURL string) {
+	resources, err := h.resources(filepath.Join(h.dir, filepath.FromSlash(name)))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = defaultLimit
+	}
+	offset = max(0, min(offset, len(resources)))
+	end := min(offset+limit, len(resources))
+
+	pageURL := func(offset int) *string {
+		u := baseURL + name + "/?offset=" + strconv.Itoa(offset) + "&limit=" + strconv.Itoa(limit)
+		return &u
+	}
+
+	list := resourceList{
+		Count:   len(resources),
+		Results: []namedAPIResource{},
+	}
+	if end < len(resources) {
+		list.Next = pageURL(end)
+	}
+	if offset > 0 {
+		list.Previous = pageURL(max(0, offset-limit))
+	}
+	for _, res := range resources[offset:end] {
+		url := baseURL + name + "/" + res.Name + "/"
+		if res.ID != 0 {
+			url = baseURL + name + "/" + strconv.Itoa(res.ID) + "/"
+		}
+		list.Results = append(list.Results, namedAPIResource{Name: res.Name, URL: url})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(list)
+}
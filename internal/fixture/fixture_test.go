@@ -0,0 +1,59 @@
+package fixture
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chandanbsd/pokedex/internal/pokeapi"
+	"github.com/chandanbsd/pokedex/internal/pokecache"
+)
+
+func newTestClient(t *testing.T) *pokeapi.Client {
+	server := httptest.NewServer(NewHandler("../../fixtures/pokeapi"))
+	t.Cleanup(server.Close)
+
+	cache := pokecache.NewCache(time.Minute)
+	t.Cleanup(cache.Close)
+
+	return pokeapi.NewClient(cache, pokeapi.WithBaseURL(server.URL+PathPrefix))
+}
+
+func TestServePokemon(t *testing.T) {
+	client := newTestClient(t)
+
+	pokemon, err := client.GetPokemon("pikachu")
+	if err != nil {
+		t.Fatalf("GetPokemon: %v", err)
+	}
+	if pokemon.ID != 25 {
+		t.Errorf("expected pikachu to have ID 25, got %d", pokemon.ID)
+	}
+	if !strings.HasPrefix(pokemon.Species.URL, client.BaseURL()) {
+		t.Errorf("expected PokeAPI URLs to be rewritten, got %s", pokemon.Species.URL)
+	}
+
+	byID, err := client.GetPokemon("25")
+	if err != nil {
+		t.Fatalf("GetPokemon by ID: %v", err)
+	}
+	if byID.Name != "pikachu" {
+		t.Errorf("expected ID 25 to be pikachu, got %s", byID.Name)
+	}
+}
+
+func TestServeList(t *testing.T) {
+	client := newTestClient(t)
+
+	page, err := client.ListLocationAreas(0)
+	if err != nil {
+		t.Fatalf("ListLocationAreas: %v", err)
+	}
+	if len(page.Results) == 0 || page.Results[0].Name != "canalave-city-area" {
+		t.Errorf("expected location areas ordered by ID, got %v", page.Results)
+	}
+	if page.Previous != nil {
+		t.Errorf("expected no previous page")
+	}
+}
@@ -0,0 +1,18 @@
+package model
+
+// Ability is the ability resource.
+type Ability struct {
+	ID            int                          `json:"id"`
+	Name          string                       `json:"name"`
+	IsMainSeries  bool                         `json:"is_main_series"`
+	Generation    NamedAPIResource[Generation] `json:"generation"`
+	Names         []Name                       `json:"names"`
+	EffectEntries []Effect                     `json:"effect_entries"`
+	Pokemon       []AbilityPokemon             `json:"pokemon"`
+}
+
+type AbilityPokemon struct {
+	IsHidden bool                      `json:"is_hidden"`
+	Slot     int                       `json:"slot"`
+	Pokemon  NamedAPIResource[Pokemon] `json:"pokemon"`
+}
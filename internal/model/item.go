@@ -0,0 +1,35 @@
+package model
+
+// Item is the item resource. Cost is the price in Poke Dollars at a Poke
+// Mart, or zero for items that cannot be bought.
+type Item struct {
+	ID                int                                `json:"id"`
+	Name              string                             `json:"name"`
+	Cost              int                                `json:"cost"`
+	FlingPower        *int                               `json:"fling_power"`
+	FlingEffect       *NamedAPIResource[ItemFlingEffect] `json:"fling_effect"`
+	Attributes        []NamedAPIResource[ItemAttribute]  `json:"attributes"`
+	Category          NamedAPIResource[ItemCategory]     `json:"category"`
+	EffectEntries     []Effect                           `json:"effect_entries"`
+	FlavorTextEntries []ItemFlavorText                   `json:"flavor_text_entries"`
+	GameIndices       []GenerationGameIndex              `json:"game_indices"`
+	Names             []Name                             `json:"names"`
+	Sprites           ItemSprites                        `json:"sprites"`
+	HeldByPokemon     []ItemHolderPokemon                `json:"held_by_pokemon"`
+	BabyTriggerFor    *APIResource[EvolutionChain]       `json:"baby_trigger_for"`
+}
+
+type ItemFlavorText struct {
+	Text         string                         `json:"text"`
+	VersionGroup NamedAPIResource[VersionGroup] `json:"version_group"`
+	Language     NamedAPIResource[Language]     `json:"language"`
+}
+
+type ItemSprites struct {
+	Default string `json:"default"`
+}
+
+type ItemHolderPokemon struct {
+	Pokemon        NamedAPIResource[Pokemon] `json:"pokemon"`
+	VersionDetails []PokemonHeldItemVersion  `json:"version_details"`
+}
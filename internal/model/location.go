@@ -0,0 +1,74 @@
+package model
+
+// Region is the region resource, such as kanto or sinnoh.
+type Region struct {
+	ID             int                              `json:"id"`
+	Name           string                           `json:"name"`
+	Names          []Name                           `json:"names"`
+	Locations      []NamedAPIResource[Location]     `json:"locations"`
+	MainGeneration *NamedAPIResource[Generation]    `json:"main_generation"`
+	Pokedexes      []NamedAPIResource[Pokedex]      `json:"pokedexes"`
+	VersionGroups  []NamedAPIResource[VersionGroup] `json:"version_groups"`
+}
+
+// Location is the location resource: a town, route or dungeon made up of
+// one or more location areas.
+type Location struct {
+	ID          int                              `json:"id"`
+	Name        string                           `json:"name"`
+	Region      *NamedAPIResource[Region]        `json:"region"`
+	Names       []Name                           `json:"names"`
+	GameIndices []GenerationGameIndex            `json:"game_indices"`
+	Areas       []NamedAPIResource[LocationArea] `json:"areas"`
+}
+
+// LocationArea is the location-area resource: part of a location along
+// with the Pokemon that can be encountered there.
+type LocationArea struct {
+	ID                   int                        `json:"id"`
+	Name                 string                     `json:"name"`
+	GameIndex            int                        `json:"game_index"`
+	EncounterMethodRates []EncounterMethodRate      `json:"encounter_method_rates"`
+	Location             NamedAPIResource[Location] `json:"location"`
+	Names                []Name                     `json:"names"`
+	PokemonEncounters    []PokemonEncounter         `json:"pokemon_encounters"`
+}
+
+// LocationAreaList is one page of the location-area resource list.
+type LocationAreaList = APIResourceList[LocationArea]
+
+// EncounterMethodRate is how often an encounter method, such as walking
+// in tall grass, triggers an encounter in each version.
+type EncounterMethodRate struct {
+	EncounterMethod NamedAPIResource[EncounterMethod] `json:"encounter_method"`
+	VersionDetails  []EncounterVersionDetails         `json:"version_details"`
+}
+
+type EncounterVersionDetails struct {
+	Rate    int                       `json:"rate"`
+	Version NamedAPIResource[Version] `json:"version"`
+}
+
+// PokemonEncounter lists how a Pokemon can be encountered in an area.
+type PokemonEncounter struct {
+	Pokemon        NamedAPIResource[Pokemon] `json:"pokemon"`
+	VersionDetails []VersionEncounterDetail  `json:"version_details"`
+}
+
+// VersionEncounterDetail lists the encounters possible in one version.
+// MaxChance is the combined chance of all of them.
+type VersionEncounterDetail struct {
+	Version          NamedAPIResource[Version] `json:"version"`
+	MaxChance        int                       `json:"max_chance"`
+	EncounterDetails []Encounter               `json:"encounter_details"`
+}
+
+// Encounter is a single way of meeting a Pokemon, with the percentage
+// chance of it happening and the range of levels it is met at.
+type Encounter struct {
+	MinLevel        int                                         `json:"min_level"`
+	MaxLevel        int                                         `json:"max_level"`
+	ConditionValues []NamedAPIResource[EncounterConditionValue] `json:"condition_values"`
+	Chance          int                                         `json:"chance"`
+	Method          NamedAPIResource[EncounterMethod]           `json:"method"`
+}
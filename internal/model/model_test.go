@@ -0,0 +1,56 @@
+package model
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+func decodeFixture[T any](t *testing.T, path string) T {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		t.Fatalf("decoding %s: %v", path, err)
+	}
+	return v
+}
+
+func TestDecodePokemon(t *testing.T) {
+	pokemon := decodeFixture[Pokemon](t, "../../fixtures/pokeapi/pokemon/pikachu.json")
+
+	if pokemon.ID != 25 || pokemon.Name != "pikachu" {
+		t.Errorf("expected pikachu (25), got %s (%d)", pokemon.Name, pokemon.ID)
+	}
+	if pokemon.Species.Name != "pikachu" {
+		t.Errorf("expected species pikachu, got %q", pokemon.Species.Name)
+	}
+	if len(pokemon.Types) != 1 || pokemon.Types[0].Type.Name != "electric" {
+		t.Errorf("expected a single electric type, got %+v", pokemon.Types)
+	}
+	if len(pokemon.Stats) != 6 || pokemon.Stats[5].Stat.Name != "speed" {
+		t.Errorf("expected six stats ending in speed, got %+v", pokemon.Stats)
+	}
+}
+
+func TestDecodeLocationArea(t *testing.T) {
+	area := decodeFixture[LocationArea](t, "../../fixtures/pokeapi/location-area/canalave-city-area.json")
+
+	if area.Location.Name != "canalave-city" {
+		t.Errorf("expected location canalave-city, got %q", area.Location.Name)
+	}
+	if len(area.PokemonEncounters) == 0 {
+		t.Fatal("expected encounters")
+	}
+	details := area.PokemonEncounters[0].VersionDetails
+	if len(details) == 0 || len(details[0].EncounterDetails) == 0 {
+		t.Fatalf("expected encounter details, got %+v", details)
+	}
+	if encounter := details[0].EncounterDetails[0]; encounter.Chance == 0 || encounter.Method.Name == "" {
+		t.Errorf("expected a chance and method, got %+v", encounter)
+	}
+}
@@ -0,0 +1,19 @@
+package model
+
+// Move is the move resource. Accuracy, Power and PP are nil for moves
+// that do not use them, such as status moves that never miss.
+type Move struct {
+	ID            int                               `json:"id"`
+	Name          string                            `json:"name"`
+	Accuracy      *int                              `json:"accuracy"`
+	EffectChance  *int                              `json:"effect_chance"`
+	PP            *int                              `json:"pp"`
+	Priority      int                               `json:"priority"`
+	Power         *int                              `json:"power"`
+	DamageClass   NamedAPIResource[MoveDamageClass] `json:"damage_class"`
+	EffectEntries []Effect                          `json:"effect_entries"`
+	Generation    NamedAPIResource[Generation]      `json:"generation"`
+	Names         []Name                            `json:"names"`
+	Target        NamedAPIResource[MoveTarget]      `json:"target"`
+	Type          NamedAPIResource[Type]            `json:"type"`
+}
@@ -0,0 +1,281 @@
+package model
+
+// Pokemon is the pokemon resource: one form of a species, with its stats,
+// types, abilities and the moves it can learn.
+type Pokemon struct {
+	ID                     int                              `json:"id"`
+	Name                   string                           `json:"name"`
+	BaseExperience         int                              `json:"base_experience"`
+	Height                 int                              `json:"height"`
+	Weight                 int                              `json:"weight"`
+	Order                  int                              `json:"order"`
+	IsDefault              bool                             `json:"is_default"`
+	Abilities              []PokemonAbility                 `json:"abilities"`
+	Cries                  PokemonCries                     `json:"cries"`
+	Forms                  []NamedAPIResource[PokemonForm]  `json:"forms"`
+	GameIndices            []VersionGameIndex               `json:"game_indices"`
+	HeldItems              []PokemonHeldItem                `json:"held_items"`
+	LocationAreaEncounters string                           `json:"location_area_encounters"`
+	Moves                  []PokemonMove                    `json:"moves"`
+	PastAbilities          []PokemonAbilityPast             `json:"past_abilities"`
+	PastTypes              []PokemonTypePast                `json:"past_types"`
+	Species                NamedAPIResource[PokemonSpecies] `json:"species"`
+	Sprites                struct {
+		BackDefault      string `json:"back_default"`
+		BackFemale       string `json:"back_female"`
+		BackShiny        string `json:"back_shiny"`
+		BackShinyFemale  string `json:"back_shiny_female"`
+		FrontDefault     string `json:"front_default"`
+		FrontFemale      string `json:"front_female"`
+		FrontShiny       string `json:"front_shiny"`
+		FrontShinyFemale string `json:"front_shiny_female"`
+		Other            struct {
+			DreamWorld struct {
+				FrontDefault string      `json:"front_default"`
+				FrontFemale  interface{} `json:"front_female"`
+			} `json:"dream_world"`
+			Home struct {
+				FrontDefault     string `json:"front_default"`
+				FrontFemale      string `json:"front_female"`
+				FrontShiny       string `json:"front_shiny"`
+				FrontShinyFemale string `json:"front_shiny_female"`
+			} `json:"home"`
+			OfficialArtwork struct {
+				FrontDefault string `json:"front_default"`
+				FrontShiny   string `json:"front_shiny"`
+			} `json:"official-artwork"`
+			Showdown struct {
+				BackDefault      string      `json:"back_default"`
+				BackFemale       string      `json:"back_female"`
+				BackShiny        string      `json:"back_shiny"`
+				BackShinyFemale  interface{} `json:"back_shiny_female"`
+				FrontDefault     string      `json:"front_default"`
+				FrontFemale      string      `json:"front_female"`
+				FrontShiny       string      `json:"front_shiny"`
+				FrontShinyFemale string      `json:"front_shiny_female"`
+			} `json:"showdown"`
+		} `json:"other"`
+		Versions struct {
+			GenerationI struct {
+				RedBlue struct {
+					BackDefault      string `json:"back_default"`
+					BackGray         string `json:"back_gray"`
+					BackTransparent  string `json:"back_transparent"`
+					FrontDefault     string `json:"front_default"`
+					FrontGray        string `json:"front_gray"`
+					FrontTransparent string `json:"front_transparent"`
+				} `json:"red-blue"`
+				Yellow struct {
+					BackDefault      string `json:"back_default"`
+					BackGray         string `json:"back_gray"`
+					BackTransparent  string `json:"back_transparent"`
+					FrontDefault     string `json:"front_default"`
+					FrontGray        string `json:"front_gray"`
+					FrontTransparent string `json:"front_transparent"`
+				} `json:"yellow"`
+			} `json:"generation-i"`
+			GenerationIi struct {
+				Crystal struct {
+					BackDefault           string `json:"back_default"`
+					BackShiny             string `json:"back_shiny"`
+					BackShinyTransparent  string `json:"back_shiny_transparent"`
+					BackTransparent       string `json:"back_transparent"`
+					FrontDefault          string `json:"front_default"`
+					FrontShiny            string `json:"front_shiny"`
+					FrontShinyTransparent string `json:"front_shiny_transparent"`
+					FrontTransparent      string `json:"front_transparent"`
+				} `json:"crystal"`
+				Gold struct {
+					BackDefault      string `json:"back_default"`
+					BackShiny        string `json:"back_shiny"`
+					FrontDefault     string `json:"front_default"`
+					FrontShiny       string `json:"front_shiny"`
+					FrontTransparent string `json:"front_transparent"`
+				} `json:"gold"`
+				Silver struct {
+					BackDefault      string `json:"back_default"`
+					BackShiny        string `json:"back_shiny"`
+					FrontDefault     string `json:"front_default"`
+					FrontShiny       string `json:"front_shiny"`
+					FrontTransparent string `json:"front_transparent"`
+				} `json:"silver"`
+			} `json:"generation-ii"`
+			GenerationIii struct {
+				Emerald struct {
+					FrontDefault string `json:"front_default"`
+					FrontShiny   string `json:"front_shiny"`
+				} `json:"emerald"`
+				FireredLeafgreen struct {
+					BackDefault  string `json:"back_default"`
+					BackShiny    string `json:"back_shiny"`
+					FrontDefault string `json:"front_default"`
+					FrontShiny   string `json:"front_shiny"`
+				} `json:"firered-leafgreen"`
+				RubySapphire struct {
+					BackDefault  string `json:"back_default"`
+					BackShiny    string `json:"back_shiny"`
+					FrontDefault string `json:"front_default"`
+					FrontShiny   string `json:"front_shiny"`
+				} `json:"ruby-sapphire"`
+			} `json:"generation-iii"`
+			GenerationIv struct {
+				DiamondPearl struct {
+					BackDefault      string `json:"back_default"`
+					BackFemale       string `json:"back_female"`
+					BackShiny        string `json:"back_shiny"`
+					BackShinyFemale  string `json:"back_shiny_female"`
+					FrontDefault     string `json:"front_default"`
+					FrontFemale      string `json:"front_female"`
+					FrontShiny       string `json:"front_shiny"`
+					FrontShinyFemale string `json:"front_shiny_female"`
+				} `json:"diamond-pearl"`
+				HeartgoldSoulsilver struct {
+					BackDefault      string `json:"back_default"`
+					BackFemale       string `json:"back_female"`
+					BackShiny        string `json:"back_shiny"`
+					BackShinyFemale  string `json:"back_shiny_female"`
+					FrontDefault     string `json:"front_default"`
+					FrontFemale      string `json:"front_female"`
+					FrontShiny       string `json:"front_shiny"`
+					FrontShinyFemale string `json:"front_shiny_female"`
+				} `json:"heartgold-soulsilver"`
+				Platinum struct {
+					BackDefault      string `json:"back_default"`
+					BackFemale       string `json:"back_female"`
+					BackShiny        string `json:"back_shiny"`
+					BackShinyFemale  string `json:"back_shiny_female"`
+					FrontDefault     string `json:"front_default"`
+					FrontFemale      string `json:"front_female"`
+					FrontShiny       string `json:"front_shiny"`
+					FrontShinyFemale string `json:"front_shiny_female"`
+				} `json:"platinum"`
+			} `json:"generation-iv"`
+			GenerationV struct {
+				BlackWhite struct {
+					Animated struct {
+						BackDefault      string `json:"back_default"`
+						BackFemale       string `json:"back_female"`
+						BackShiny        string `json:"back_shiny"`
+						BackShinyFemale  string `json:"back_shiny_female"`
+						FrontDefault     string `json:"front_default"`
+						FrontFemale      string `json:"front_female"`
+						FrontShiny       string `json:"front_shiny"`
+						FrontShinyFemale string `json:"front_shiny_female"`
+					} `json:"animated"`
+					BackDefault      string `json:"back_default"`
+					BackFemale       string `json:"back_female"`
+					BackShiny        string `json:"back_shiny"`
+					BackShinyFemale  string `json:"back_shiny_female"`
+					FrontDefault     string `json:"front_default"`
+					FrontFemale      string `json:"front_female"`
+					FrontShiny       string `json:"front_shiny"`
+					FrontShinyFemale string `json:"front_shiny_female"`
+				} `json:"black-white"`
+			} `json:"generation-v"`
+			GenerationVi struct {
+				OmegarubyAlphasapphire struct {
+					FrontDefault     string `json:"front_default"`
+					FrontFemale      string `json:"front_female"`
+					FrontShiny       string `json:"front_shiny"`
+					FrontShinyFemale string `json:"front_shiny_female"`
+				} `json:"omegaruby-alphasapphire"`
+				XY struct {
+					FrontDefault     string `json:"front_default"`
+					FrontFemale      string `json:"front_female"`
+					FrontShiny       string `json:"front_shiny"`
+					FrontShinyFemale string `json:"front_shiny_female"`
+				} `json:"x-y"`
+			} `json:"generation-vi"`
+			GenerationVii struct {
+				Icons struct {
+					FrontDefault string      `json:"front_default"`
+					FrontFemale  interface{} `json:"front_female"`
+				} `json:"icons"`
+				UltraSunUltraMoon struct {
+					FrontDefault     string `json:"front_default"`
+					FrontFemale      string `json:"front_female"`
+					FrontShiny       string `json:"front_shiny"`
+					FrontShinyFemale string `json:"front_shiny_female"`
+				} `json:"ultra-sun-ultra-moon"`
+			} `json:"generation-vii"`
+			GenerationViii struct {
+				Icons struct {
+					FrontDefault string `json:"front_default"`
+					FrontFemale  string `json:"front_female"`
+				} `json:"icons"`
+			} `json:"generation-viii"`
+		} `json:"versions"`
+	} `json:"sprites"`
+	Stats []PokemonStat `json:"stats"`
+	Types []PokemonType `json:"types"`
+}
+
+type PokemonAbility struct {
+	IsHidden bool                      `json:"is_hidden"`
+	Slot     int                       `json:"slot"`
+	Ability  NamedAPIResource[Ability] `json:"ability"`
+}
+
+// PokemonAbilityPast lists the abilities a Pokemon had up to a generation.
+// Ability is nil for slots the Pokemon did not have back then.
+type PokemonAbilityPast struct {
+	Generation NamedAPIResource[Generation] `json:"generation"`
+	Abilities  []struct {
+		IsHidden bool                       `json:"is_hidden"`
+		Slot     int                        `json:"slot"`
+		Ability  *NamedAPIResource[Ability] `json:"ability"`
+	} `json:"abilities"`
+}
+
+type PokemonCries struct {
+	Latest string `json:"latest"`
+	Legacy string `json:"legacy"`
+}
+
+type PokemonHeldItem struct {
+	Item           NamedAPIResource[Item]   `json:"item"`
+	VersionDetails []PokemonHeldItemVersion `json:"version_details"`
+}
+
+type PokemonHeldItemVersion struct {
+	Rarity  int                       `json:"rarity"`
+	Version NamedAPIResource[Version] `json:"version"`
+}
+
+type PokemonMove struct {
+	Move                NamedAPIResource[Move] `json:"move"`
+	VersionGroupDetails []PokemonMoveVersion   `json:"version_group_details"`
+}
+
+// PokemonMoveVersion says how a Pokemon learns a move in one version
+// group. LevelLearnedAt is only meaningful for the "level-up" method.
+type PokemonMoveVersion struct {
+	LevelLearnedAt  int                               `json:"level_learned_at"`
+	MoveLearnMethod NamedAPIResource[MoveLearnMethod] `json:"move_learn_method"`
+	Order           *int                              `json:"order"`
+	VersionGroup    NamedAPIResource[VersionGroup]    `json:"version_group"`
+}
+
+type PokemonStat struct {
+	BaseStat int                    `json:"base_stat"`
+	Effort   int                    `json:"effort"`
+	Stat     NamedAPIResource[Stat] `json:"stat"`
+}
+
+type PokemonType struct {
+	Slot int                    `json:"slot"`
+	Type NamedAPIResource[Type] `json:"type"`
+}
+
+// PokemonTypePast lists the types a Pokemon had up to a generation.
+type PokemonTypePast struct {
+	Generation NamedAPIResource[Generation] `json:"generation"`
+	Types      []PokemonType                `json:"types"`
+}
+
+// LocationAreaEncounter is one entry of the list at a Pokemon's
+// LocationAreaEncounters URL: an area it can be found in, per version.
+type LocationAreaEncounter struct {
+	LocationArea   NamedAPIResource[LocationArea] `json:"location_area"`
+	VersionDetails []VersionEncounterDetail       `json:"version_details"`
+}
@@ -0,0 +1,157 @@
+// Package model holds the PokeAPI resource types.
+//
+// Resources refer to each other through NamedAPIResource and APIResource,
+// whose type parameter names the resource the URL points at. It is not
+// stored anywhere; it documents what fetching the URL returns and keeps
+// references to different resources from being mixed up.
+package model
+
+// NamedAPIResource refers to another resource by name and URL.
+type NamedAPIResource[T any] struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// APIResource refers to another resource by URL only, for resources such
+// as evolution chains that have no name.
+type APIResource[T any] struct {
+	URL string `json:"url"`
+}
+
+// APIResourceList is one page of a resource list endpoint such as
+// /location-area/.
+type APIResourceList[T any] struct {
+	Count    int                   `json:"count"`
+	Next     *string               `json:"next"`
+	Previous *string               `json:"previous"`
+	Results  []NamedAPIResource[T] `json:"results"`
+}
+
+// Name is a resource's name in one language.
+type Name struct {
+	Name     string                     `json:"name"`
+	Language NamedAPIResource[Language] `json:"language"`
+}
+
+// Effect describes what a move, ability or item does, in one language.
+type Effect struct {
+	Effect      string                     `json:"effect"`
+	ShortEffect string                     `json:"short_effect"`
+	Language    NamedAPIResource[Language] `json:"language"`
+}
+
+// GenerationGameIndex is a resource's internal index in one generation's
+// games.
+type GenerationGameIndex struct {
+	GameIndex  int                          `json:"game_index"`
+	Generation NamedAPIResource[Generation] `json:"generation"`
+}
+
+// VersionGameIndex is a resource's internal index in one game version.
+type VersionGameIndex struct {
+	GameIndex int                       `json:"game_index"`
+	Version   NamedAPIResource[Version] `json:"version"`
+}
+
+// The resources below are only ever referred to, never fetched, so they
+// carry just their identity.
+
+type Language struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type Generation struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type VersionGroup struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type Stat struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type EncounterMethod struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type EncounterConditionValue struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type MoveLearnMethod struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type MoveDamageClass struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type MoveTarget struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type PokemonForm struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type GrowthRate struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type EggGroup struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type PokemonColor struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type PokemonShape struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type PokemonHabitat struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type Pokedex struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type EvolutionTrigger struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type ItemCategory struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type ItemAttribute struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type ItemFlingEffect struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
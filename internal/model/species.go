@@ -0,0 +1,94 @@
+package model
+
+// PokemonSpecies is the pokemon-species resource, shared by every form of
+// a Pokemon, and where its evolution chain is found.
+type PokemonSpecies struct {
+	ID                   int                               `json:"id"`
+	Name                 string                            `json:"name"`
+	Order                int                               `json:"order"`
+	GenderRate           int                               `json:"gender_rate"`
+	CaptureRate          int                               `json:"capture_rate"`
+	BaseHappiness        *int                              `json:"base_happiness"`
+	IsBaby               bool                              `json:"is_baby"`
+	IsLegendary          bool                              `json:"is_legendary"`
+	IsMythical           bool                              `json:"is_mythical"`
+	HatchCounter         *int                              `json:"hatch_counter"`
+	HasGenderDifferences bool                              `json:"has_gender_differences"`
+	FormsSwitchable      bool                              `json:"forms_switchable"`
+	GrowthRate           NamedAPIResource[GrowthRate]      `json:"growth_rate"`
+	PokedexNumbers       []PokemonSpeciesDexEntry          `json:"pokedex_numbers"`
+	EggGroups            []NamedAPIResource[EggGroup]      `json:"egg_groups"`
+	Color                NamedAPIResource[PokemonColor]    `json:"color"`
+	Shape                *NamedAPIResource[PokemonShape]   `json:"shape"`
+	EvolvesFromSpecies   *NamedAPIResource[PokemonSpecies] `json:"evolves_from_species"`
+	EvolutionChain       *APIResource[EvolutionChain]      `json:"evolution_chain"`
+	Habitat              *NamedAPIResource[PokemonHabitat] `json:"habitat"`
+	Generation           NamedAPIResource[Generation]      `json:"generation"`
+	Names                []Name                            `json:"names"`
+	FlavorTextEntries    []FlavorText                      `json:"flavor_text_entries"`
+	Genera               []Genus                           `json:"genera"`
+	Varieties            []PokemonSpeciesVariety           `json:"varieties"`
+}
+
+type PokemonSpeciesDexEntry struct {
+	EntryNumber int                       `json:"entry_number"`
+	Pokedex     NamedAPIResource[Pokedex] `json:"pokedex"`
+}
+
+// FlavorText is a Pokedex entry or item description as shown in one
+// version of the games.
+type FlavorText struct {
+	FlavorText string                     `json:"flavor_text"`
+	Language   NamedAPIResource[Language] `json:"language"`
+	Version    *NamedAPIResource[Version] `json:"version"`
+}
+
+type Genus struct {
+	Genus    string                     `json:"genus"`
+	Language NamedAPIResource[Language] `json:"language"`
+}
+
+type PokemonSpeciesVariety struct {
+	IsDefault bool                      `json:"is_default"`
+	Pokemon   NamedAPIResource[Pokemon] `json:"pokemon"`
+}
+
+// EvolutionChain is the evolution-chain resource: the tree of species
+// that evolve into one another, rooted at the unevolved species.
+type EvolutionChain struct {
+	ID              int                     `json:"id"`
+	BabyTriggerItem *NamedAPIResource[Item] `json:"baby_trigger_item"`
+	Chain           ChainLink               `json:"chain"`
+}
+
+// ChainLink is one species in an evolution chain. EvolutionDetails holds
+// the ways of evolving into it from its parent, and is empty at the root.
+type ChainLink struct {
+	IsBaby           bool                             `json:"is_baby"`
+	Species          NamedAPIResource[PokemonSpecies] `json:"species"`
+	EvolutionDetails []EvolutionDetail                `json:"evolution_details"`
+	EvolvesTo        []ChainLink                      `json:"evolves_to"`
+}
+
+// EvolutionDetail is one set of conditions that triggers an evolution.
+// Every condition that is set must be met; unset ones are nil or zero.
+type EvolutionDetail struct {
+	Trigger               NamedAPIResource[EvolutionTrigger] `json:"trigger"`
+	Item                  *NamedAPIResource[Item]            `json:"item"`
+	Gender                *int                               `json:"gender"`
+	HeldItem              *NamedAPIResource[Item]            `json:"held_item"`
+	KnownMove             *NamedAPIResource[Move]            `json:"known_move"`
+	KnownMoveType         *NamedAPIResource[Type]            `json:"known_move_type"`
+	Location              *NamedAPIResource[Location]        `json:"location"`
+	MinLevel              *int                               `json:"min_level"`
+	MinHappiness          *int                               `json:"min_happiness"`
+	MinBeauty             *int                               `json:"min_beauty"`
+	MinAffection          *int                               `json:"min_affection"`
+	NeedsOverworldRain    bool                               `json:"needs_overworld_rain"`
+	PartySpecies          *NamedAPIResource[PokemonSpecies]  `json:"party_species"`
+	PartyType             *NamedAPIResource[Type]            `json:"party_type"`
+	RelativePhysicalStats *int                               `json:"relative_physical_stats"`
+	TimeOfDay             string                             `json:"time_of_day"`
+	TradeSpecies          *NamedAPIResource[PokemonSpecies]  `json:"trade_species"`
+	TurnUpsideDown        bool                               `json:"turn_upside_down"`
+}
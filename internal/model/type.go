@@ -0,0 +1,39 @@
+package model
+
+// Type is the type resource, such as fire or water, with how it fares
+// against the other types.
+type Type struct {
+	ID                  int                                `json:"id"`
+	Name                string                             `json:"name"`
+	DamageRelations     TypeRelations                      `json:"damage_relations"`
+	PastDamageRelations []TypeRelationsPast                `json:"past_damage_relations"`
+	GameIndices         []GenerationGameIndex              `json:"game_indices"`
+	Generation          NamedAPIResource[Generation]       `json:"generation"`
+	MoveDamageClass     *NamedAPIResource[MoveDamageClass] `json:"move_damage_class"`
+	Names               []Name                             `json:"names"`
+	Pokemon             []TypePokemon                      `json:"pokemon"`
+	Moves               []NamedAPIResource[Move]           `json:"moves"`
+}
+
+// TypeRelations lists the types this type deals no, half or double damage
+// to, and takes no, half or double damage from.
+type TypeRelations struct {
+	NoDamageTo       []NamedAPIResource[Type] `json:"no_damage_to"`
+	HalfDamageTo     []NamedAPIResource[Type] `json:"half_damage_to"`
+	DoubleDamageTo   []NamedAPIResource[Type] `json:"double_damage_to"`
+	NoDamageFrom     []NamedAPIResource[Type] `json:"no_damage_from"`
+	HalfDamageFrom   []NamedAPIResource[Type] `json:"half_damage_from"`
+	DoubleDamageFrom []NamedAPIResource[Type] `json:"double_damage_from"`
+}
+
+// TypeRelationsPast holds the damage relations a type had up to a
+// generation.
+type TypeRelationsPast struct {
+	Generation      NamedAPIResource[Generation] `json:"generation"`
+	DamageRelations TypeRelations                `json:"damage_relations"`
+}
+
+type TypePokemon struct {
+	Slot    int                       `json:"slot"`
+	Pokemon NamedAPIResource[Pokemon] `json:"pokemon"`
+}
@@ -0,0 +1,10 @@
+package model
+
+// Version is the version resource, one game such as red or diamond, and
+// the version group of games it was released alongside.
+type Version struct {
+	ID           int                            `json:"id"`
+	Name         string                         `json:"name"`
+	Names        []Name                         `json:"names"`
+	VersionGroup NamedAPIResource[VersionGroup] `json:"version_group"`
+}
@@ -0,0 +1,307 @@
+package pokeapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/chandanbsd/pokedex/internal/model"
+	"github.com/chandanbsd/pokedex/internal/pokecache"
+)
+
+// DefaultBaseURL is the root of the public PokeAPI.
+const DefaultBaseURL = "https://pokeapi.co/api/v2/"
+
+// LocationAreaPageSize is the number of location areas on each page
+// returned by ListLocationAreas.
+const LocationAreaPageSize = 20
+
+// Client fetches PokeAPI resources, caching every response.
+type Client struct {
+	baseURL              string
+	userAgent            string
+	httpClient           *http.Client
+	cache                *pokecache.Cache
+	staleWhileRevalidate bool
+
+	// maxRetries bounds how many times a failed request is repeated;
+	// backoff is the delay before the first retry, doubling up to
+	// maxBackoff.
+	maxRetries int
+	backoff    time.Duration
+	maxBackoff time.Duration
+	sleep      func(time.Duration)
+}
+
+// Option configures optional behaviour of a Client.
+type Option func(*Client)
+
+// WithBaseURL points the client at a different PokeAPI deployment.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		if !strings.HasSuffix(baseURL, "/") {
+			baseURL += "/"
+		}
+		c.baseURL = baseURL
+	}
+}
+
+// WithTimeout bounds how long a single request may take.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.httpClient.Timeout = timeout
+	}
+}
+
+// WithTransport sends requests through rt instead of the default
+// transport, for example to record or replay traffic.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Client) {
+		c.httpClient.Transport = rt
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithStaleWhileRevalidate serves stale cache entries immediately and
+// revalidates them in the background instead of waiting on the network.
+func WithStaleWhileRevalidate(enabled bool) Option {
+	return func(c *Client) {
+		c.staleWhileRevalidate = enabled
+	}
+}
+
+// WithRetries sets how many times rate-limited, failing or unreachable
+// requests are retried, and the delay before the first retry. Delays
+// double on each attempt unless the server sends Retry-After.
+func WithRetries(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.backoff = backoff
+	}
+}
+
+// NewClient returns a client that caches responses in cache.
+func NewClient(cache *pokecache.Cache, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    DefaultBaseURL,
+		userAgent:  "pokedex",
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		cache:      cache,
+		maxRetries: 3,
+		backoff:    500 * time.Millisecond,
+		maxBackoff: 30 * time.Second,
+		sleep:      time.Sleep,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// BaseURL returns the root URL resources are fetched from.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// GetPokemon fetches the pokemon resource with the given name or ID.
+func (c *Client) GetPokemon(name string) (model.Pokemon, error) {
+	return get[model.Pokemon](c, "pokemon", name)
+}
+
+// GetPokemonSpecies fetches the pokemon-species resource with the given
+// name or ID.
+func (c *Client) GetPokemonSpecies(name string) (model.PokemonSpecies, error) {
+	return get[model.PokemonSpecies](c, "pokemon-species", name)
+}
+
+// GetEvolutionChain fetches the evolution-chain resource with the given ID.
+func (c *Client) GetEvolutionChain(id string) (model.EvolutionChain, error) {
+	return get[model.EvolutionChain](c, "evolution-chain", id)
+}
+
+// GetType fetches the type resource with the given name or ID.
+func (c *Client) GetType(name string) (model.Type, error) {
+	return get[model.Type](c, "type", name)
+}
+
+// GetMove fetches the move resource with the given name or ID.
+func (c *Client) GetMove(name string) (model.Move, error) {
+	return get[model.Move](c, "move", name)
+}
+
+// GetAbility fetches the ability resource with the given name or ID.
+func (c *Client) GetAbility(name string) (model.Ability, error) {
+	return get[model.Ability](c, "ability", name)
+}
+
+// GetItem fetches the item resource with the given name or ID.
+func (c *Client) GetItem(name string) (model.Item, error) {
+	return get[model.Item](c, "item", name)
+}
+
+// GetVersion fetches the version resource with the given name or ID.
+func (c *Client) GetVersion(name string) (model.Version, error) {
+	return get[model.Version](c, "version", name)
+}
+
+// GetLocation fetches the location resource with the given name or ID.
+func (c *Client) GetLocation(name string) (model.Location, error) {
+	return get[model.Location](c, "location", name)
+}
+
+// GetRegion fetches the region resource with the given name or ID.
+func (c *Client) GetRegion(name string) (model.Region, error) {
+	return get[model.Region](c, "region", name)
+}
+
+// GetLocationArea fetches the location-area resource with the given name
+// or ID.
+func (c *Client) GetLocationArea(name string) (model.LocationArea, error) {
+	return get[model.LocationArea](c, "location-area", name)
+}
+
+// ListLocationAreas fetches the given zero-based page of location areas.
+func (c *Client) ListLocationAreas(page int) (model.LocationAreaList, error) {
+	var list model.LocationAreaList
+	u := fmt.Sprintf("%slocation-area/?offset=%d&limit=%d", c.baseURL, page*LocationAreaPageSize, LocationAreaPageSize)
+	err := c.getJSON(u, &list)
+	return list, err
+}
+
+// Fetch fetches the resource at url, usually taken from a reference in
+// another resource such as Pokemon.Species.
+func Fetch[T any](c *Client, url string) (T, error) {
+	var v T
+	err := c.getJSON(url, &v)
+	return v, err
+}
+
+// get fetches the named resource from the given endpoint, such as
+// "pokemon".
+func get[T any](c *Client, endpoint, name string) (T, error) {
+	var v T
+	err := c.getJSON(c.baseURL+endpoint+"/"+url.PathEscape(name), &v)
+	return v, err
+}
+
+func (c *Client) getJSON(url string, v any) error {
+	body, err := c.Get(url)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("decoding %s: %w", url, err)
+	}
+	return nil
+}
+
+// Get returns the body of url, from the cache when possible.
+func (c *Client) Get(url string) ([]byte, error) {
+	entry, fresh, ok := c.cache.Lookup(url)
+
+	if ok && fresh {
+		return entry.Value(), nil
+	}
+	if !ok {
+		return c.fetch(url, nil)
+	}
+
+	if c.staleWhileRevalidate {
+		go c.fetch(url, &entry)
+		return entry.Value(), nil
+	}
+
+	// A stale body beats none: if PokeAPI cannot be reached, keep serving
+	// what was cached so the REPL works offline. Having it, one attempt is
+	// enough rather than waiting out the retries.
+	body, err := c.fetchOnce(url, &entry)
+	if err != nil {
+		return entry.Value(), nil
+	}
+	return body, nil
+}
+
+// fetch downloads url and caches the response, retrying transient
+// failures. Error responses are never cached.
+func (c *Client) fetch(url string, stale *pokecache.CacheEntry) ([]byte, error) {
+	for attempt := 0; ; attempt++ {
+		body, err := c.fetchOnce(url, stale)
+		if err == nil || attempt >= c.maxRetries || !retryable(err) {
+			return body, err
+		}
+		c.sleep(c.retryDelay(attempt, err))
+	}
+}
+
+// retryDelay returns how long to wait before retrying after the given
+// failed attempt.
+func (c *Client) retryDelay(attempt int, err error) time.Duration {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.RetryAfter > 0 {
+		return min(statusErr.RetryAfter, c.maxBackoff)
+	}
+	return min(c.backoff<<attempt, c.maxBackoff)
+}
+
+// fetchOnce makes a single request for url. If stale is non-nil its
+// validators are sent along, and a "304 Not Modified" response refreshes
+// the cached entry instead of downloading it again.
+func (c *Client) fetchOnce(url string, stale *pokecache.CacheEntry) ([]byte, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+
+	if stale != nil {
+		if stale.ETag != "" {
+			req.Header.Set("If-None-Match", stale.ETag)
+		}
+		if stale.LastModified != "" {
+			req.Header.Set("If-Modified-Since", stale.LastModified)
+		}
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if stale != nil && res.StatusCode == http.StatusNotModified {
+		c.cache.Refresh(url)
+		return stale.Value(), nil
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return nil, &StatusError{
+			URL:        url,
+			StatusCode: res.StatusCode,
+			RetryAfter: parseRetryAfter(res.Header.Get("Retry-After"), time.Now()),
+		}
+	}
+
+	bytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	c.cache.AddWithValidators(url, bytes, res.Header.Get("ETag"), res.Header.Get("Last-Modified"))
+
+	return bytes, nil
+}
@@ -0,0 +1,222 @@
+package pokeapi
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/chandanbsd/pokedex/internal/pokecache"
+)
+
+func TestGetPokemon(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pokemon/pikachu" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"name": "pikachu", "base_experience": 112}`))
+	}))
+	defer server.Close()
+
+	cache := pokecache.NewCache(time.Minute)
+	defer cache.Close()
+	client := NewClient(cache, WithBaseURL(server.URL))
+
+	pokemon, err := client.GetPokemon("pikachu")
+	if err != nil {
+		t.Fatalf("GetPokemon: %v", err)
+	}
+	if pokemon.Name != "pikachu" || pokemon.BaseExperience != 112 {
+		t.Errorf("unexpected pokemon: %v %v", pokemon.Name, pokemon.BaseExperience)
+	}
+
+	if _, err := client.GetPokemon("pikachuu"); err == nil {
+		t.Errorf("expected an error for a missing pokemon")
+	}
+}
+
+func TestConditionalRequest(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"name": "canalave-city-area"}`))
+	}))
+	defer server.Close()
+
+	cache := pokecache.NewCache(time.Millisecond, pokecache.WithStaleTTL(time.Minute))
+	defer cache.Close()
+	client := NewClient(cache, WithBaseURL(server.URL))
+
+	if _, err := client.GetLocationArea("canalave-city-area"); err != nil {
+		t.Fatalf("GetLocationArea: %v", err)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	area, err := client.GetLocationArea("canalave-city-area")
+	if err != nil {
+		t.Fatalf("GetLocationArea after revalidation: %v", err)
+	}
+	if area.Name != "canalave-city-area" {
+		t.Errorf("expected the cached body to be served on 304, got %q", area.Name)
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests, got %d", requests)
+	}
+}
+
+func TestRetries(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch requests {
+		case 1:
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.Write([]byte(`{"name": "pikachu"}`))
+		}
+	}))
+	defer server.Close()
+
+	cache := pokecache.NewCache(time.Minute)
+	defer cache.Close()
+	client := NewClient(cache, WithBaseURL(server.URL), WithRetries(3, time.Second))
+
+	var delays []time.Duration
+	client.sleep = func(d time.Duration) {
+		delays = append(delays, d)
+	}
+
+	if _, err := client.GetPokemon("pikachu"); err != nil {
+		t.Fatalf("GetPokemon: %v", err)
+	}
+
+	if len(delays) != 2 || delays[0] != 2*time.Second || delays[1] != 2*time.Second {
+		t.Errorf("expected to wait for Retry-After then back off exponentially, got %v", delays)
+	}
+}
+
+func TestErrorsAreTypedAndNotCached(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("<html>oops</html>"))
+	}))
+	defer server.Close()
+
+	cache := pokecache.NewCache(time.Minute)
+	defer cache.Close()
+	client := NewClient(cache, WithBaseURL(server.URL), WithRetries(2, time.Millisecond))
+	client.sleep = func(time.Duration) {}
+
+	_, err := client.GetPokemon("pikachu")
+	if !errors.Is(err, ErrServer) {
+		t.Errorf("expected ErrServer, got %v", err)
+	}
+	if requests != 3 {
+		t.Errorf("expected 1 request and 2 retries, got %d requests", requests)
+	}
+	if cache.Len() != 0 {
+		t.Errorf("expected error responses to stay out of the cache")
+	}
+
+	notFound := httptest.NewServer(http.NotFoundHandler())
+	defer notFound.Close()
+	client = NewClient(cache, WithBaseURL(notFound.URL))
+
+	_, err = client.GetPokemon("pikachuu")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+// failingTransport fails every request with err, as the network does
+// when working offline.
+type failingTransport struct {
+	err      error
+	requests int
+}
+
+func (t *failingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	t.requests++
+	return nil, t.err
+}
+
+func TestOfflineServesStaleDiskEntries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name": "pikachu"}`))
+	}))
+	defer server.Close()
+
+	disk := pokecache.WithDisk(pokecache.DiskOptions{Dir: t.TempDir()})
+
+	warm := pokecache.NewCache(time.Millisecond, disk)
+	if _, err := NewClient(warm, WithBaseURL(server.URL)).GetPokemon("pikachu"); err != nil {
+		t.Fatalf("GetPokemon: %v", err)
+	}
+	warm.Close()
+
+	time.Sleep(5 * time.Millisecond)
+
+	offline := map[string]error{
+		"refused":      &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)},
+		"unreachable":  &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ENETUNREACH)},
+		"no such host": &net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "no such host", Name: "pokeapi.co", IsNotFound: true}},
+		"timeout":      &net.OpError{Op: "dial", Net: "tcp", Err: os.ErrDeadlineExceeded},
+	}
+	for name, offlineErr := range offline {
+		cache := pokecache.NewCache(time.Millisecond, disk)
+		transport := &failingTransport{err: offlineErr}
+		client := NewClient(cache, WithBaseURL(server.URL), WithTransport(transport), WithRetries(3, time.Second))
+		client.sleep = func(time.Duration) {
+			t.Errorf("%s: expected a stale entry to be served without retrying", name)
+		}
+
+		pokemon, err := client.GetPokemon("pikachu")
+		if err != nil {
+			t.Errorf("%s: expected the stale entry to be served offline, got %v", name, err)
+		} else if pokemon.Name != "pikachu" {
+			t.Errorf("%s: unexpected pokemon: %v", name, pokemon.Name)
+		}
+		if transport.requests != 1 {
+			t.Errorf("%s: expected 1 request, got %d", name, transport.requests)
+		}
+		cache.Close()
+	}
+}
+
+func TestOfflineErrorsAreNotRetried(t *testing.T) {
+	cache := pokecache.NewCache(time.Minute)
+	defer cache.Close()
+
+	for _, offlineErr := range []error{
+		os.NewSyscallError("connect", syscall.ECONNREFUSED),
+		os.NewSyscallError("connect", syscall.ENETUNREACH),
+		&net.DNSError{Err: "no such host", Name: "pokeapi.co", IsNotFound: true},
+	} {
+		transport := &failingTransport{err: &net.OpError{Op: "dial", Net: "tcp", Err: offlineErr}}
+		client := NewClient(cache, WithTransport(transport), WithRetries(3, time.Second))
+		client.sleep = func(time.Duration) {}
+
+		if _, err := client.GetPokemon("raichu"); err == nil {
+			t.Errorf("%v: expected an uncached pokemon to fail offline", offlineErr)
+		}
+		if transport.requests != 1 {
+			t.Errorf("%v: expected 1 request, got %d", offlineErr, transport.requests)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package pokeapi
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+var (
+	// ErrNotFound is returned when the requested resource does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrRateLimited is returned when PokeAPI keeps rejecting requests
+	// with "429 Too Many Requests" after every retry.
+	ErrRateLimited = errors.New("rate limited")
+	// ErrServer is returned when PokeAPI keeps failing with a 5xx status
+	// after every retry.
+	ErrServer = errors.New("server error")
+)
+
+// StatusError describes a non-2xx response. It wraps ErrNotFound,
+// ErrRateLimited or ErrServer where one applies, so callers can match it
+// with errors.Is.
+type StatusError struct {
+	URL        string
+	StatusCode int
+	// RetryAfter is the delay requested by the Retry-After header, or zero.
+	RetryAfter time.Duration
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("GET %s: %d %s", e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+func (e *StatusError) Unwrap() error {
+	switch {
+	case e.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case e.StatusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case e.StatusCode >= 500:
+		return ErrServer
+	}
+	return nil
+}
+
+// retryable reports whether a request that failed with err is worth
+// repeating.
+func retryable(err error) bool {
+	if errors.Is(err, ErrRateLimited) || errors.Is(err, ErrServer) {
+		return true
+	}
+	// Network failures, such as a refused or reset connection or a
+	// timeout, surface from the http.Client as a *url.Error wrapping a
+	// net.Error. Other transport errors are not worth repeating.
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return false
+	}
+	// Nothing is listening, as when working offline against a stopped
+	// server, or there is no network to reach it over; waiting will not
+	// change that.
+	var dnsErr *net.DNSError
+	if errors.As(urlErr.Err, &dnsErr) && dnsErr.IsNotFound {
+		return false
+	}
+	if errors.Is(urlErr.Err, syscall.ECONNREFUSED) || errors.Is(urlErr.Err, syscall.ENETUNREACH) {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(urlErr.Err, &netErr) || errors.Is(urlErr.Err, io.ErrUnexpectedEOF) || errors.Is(urlErr.Err, io.EOF)
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as
+// an HTTP date.
+func parseRetryAfter(header string, now time.Time) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(header); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
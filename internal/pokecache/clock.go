@@ -0,0 +1,30 @@
+package pokecache
+
+import "time"
+
+// Clock is the source of time for a Cache. Tests substitute their own to
+// control expiry without sleeping.
+type Clock interface {
+	Now() time.Time
+	// Tick returns a channel that delivers the time every d, and a
+	// function that stops it.
+	Tick(d time.Duration) (<-chan time.Time, func())
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) Tick(d time.Duration) (<-chan time.Time, func()) {
+	ticker := time.NewTicker(d)
+	return ticker.C, ticker.Stop
+}
+
+// WithClock replaces the wall clock used for entry timestamps and reaping.
+func WithClock(clock Clock) Option {
+	return func(c *Cache) {
+		c.clock = clock
+	}
+}
@@ -0,0 +1,149 @@
+package pokecache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DiskOptions configures the persistent second tier of a Cache.
+type DiskOptions struct {
+	// Dir holds one file per entry, named after the SHA-256 of its key.
+	Dir string
+	// MaxBytes caps the total size of the directory; the oldest entries
+	// are removed first. Zero means no limit.
+	MaxBytes int64
+	// MaxEntryBytes skips persisting entries larger than this. Zero means
+	// no limit.
+	MaxEntryBytes int64
+}
+
+type diskStore struct {
+	DiskOptions
+}
+
+type diskEntry struct {
+	Key          string        `json:"key"`
+	CreatedAt    time.Time     `json:"created_at"`
+	TTL          time.Duration `json:"ttl"`
+	ETag         string        `json:"etag,omitempty"`
+	LastModified string        `json:"last_modified,omitempty"`
+	Val          []byte        `json:"val"`
+}
+
+func newDiskStore(opts DiskOptions) (*diskStore, error) {
+	if err := os.MkdirAll(opts.Dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &diskStore{DiskOptions: opts}, nil
+}
+
+func (d *diskStore) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.Dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (d *diskStore) get(key string) (CacheEntry, bool) {
+	data, err := os.ReadFile(d.path(key))
+	if err != nil {
+		return CacheEntry{}, false
+	}
+
+	var entry diskEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Key != key {
+		return CacheEntry{}, false
+	}
+
+	return CacheEntry{
+		CreatedAt:    entry.CreatedAt,
+		ETag:         entry.ETag,
+		LastModified: entry.LastModified,
+		val:          entry.Val,
+		ttl:          entry.TTL,
+	}, true
+}
+
+func (d *diskStore) add(key string, entry CacheEntry) error {
+	if d.MaxEntryBytes > 0 && int64(len(entry.val)) > d.MaxEntryBytes {
+		return nil
+	}
+
+	data, err := json.Marshal(diskEntry{
+		Key:          key,
+		CreatedAt:    entry.CreatedAt,
+		TTL:          entry.ttl,
+		ETag:         entry.ETag,
+		LastModified: entry.LastModified,
+		Val:          entry.val,
+	})
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(d.Dir, "entry-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), d.path(key)); err != nil {
+		return err
+	}
+
+	return d.trim()
+}
+
+// trim removes the least recently written entries until the directory fits
+// within MaxBytes.
+func (d *diskStore) trim() error {
+	if d.MaxBytes <= 0 {
+		return nil
+	}
+
+	dirEntries, err := os.ReadDir(d.Dir)
+	if err != nil {
+		return err
+	}
+
+	var files []os.FileInfo
+	var total int64
+	for _, dirEntry := range dirEntries {
+		if !strings.HasSuffix(dirEntry.Name(), ".json") {
+			continue
+		}
+		info, err := dirEntry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, info)
+		total += info.Size()
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModTime().Before(files[j].ModTime())
+	})
+
+	for _, info := range files {
+		if total <= d.MaxBytes {
+			break
+		}
+		if err := os.Remove(filepath.Join(d.Dir, info.Name())); err != nil {
+			return err
+		}
+		total -= info.Size()
+	}
+
+	return nil
+}
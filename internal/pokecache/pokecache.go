@@ -1,63 +1,366 @@
 package pokecache
 
 import (
+	"container/list"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 type CacheEntry struct {
 	CreatedAt time.Time
-	val       []byte
+	// ETag and LastModified are the HTTP validators the value was served
+	// with, used to revalidate the entry once it goes stale.
+	ETag         string
+	LastModified string
+	val          []byte
+	// ttl is how long the entry stays fresh after CreatedAt; freshUntil is
+	// when Get stops returning the in-memory copy, and expiresAt is when it
+	// is reaped.
+	ttl        time.Duration
+	freshUntil time.Time
+	expiresAt  time.Time
+}
+
+// Value returns the cached bytes.
+func (e CacheEntry) Value() []byte {
+	return e.val
 }
 
 type Cache struct {
 	store map[string]CacheEntry
-	mutex sync.Mutex
+	mutex sync.RWMutex
+	disk  *diskStore
+	clock Clock
+
+	// interval is both the default TTL and how often expired entries are
+	// reaped.
+	interval  time.Duration
+	staleTTL  time.Duration
+	done      chan struct{}
+	stopped   chan struct{}
+	closeOnce sync.Once
+
+	// lru orders keys from most to least recently used; order maps each
+	// key to its element in lru.
+	lru        *list.List
+	order      map[string]*list.Element
+	size       int64
+	maxEntries int
+	maxBytes   int64
+
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	evictions atomic.Uint64
+}
+
+// Stats counts how the cache has been used since it was created.
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
 }
 
-//const cacheDuration = 5 * time.Second
-//var timeChan chan time.Time = make(chan time.Time)
+// Option configures optional behaviour of a Cache.
+type Option func(*Cache)
 
-func NewCache(interval time.Duration) *Cache {
+// WithDisk backs the cache with a directory on disk, so entries survive
+// across processes. If the directory cannot be created the cache stays
+// memory-only.
+//
+// TTLs only govern the in-memory tier; the disk tier is bounded by size.
+func WithDisk(opts DiskOptions) Option {
+	return func(c *Cache) {
+		disk, err := newDiskStore(opts)
+		if err != nil {
+			return
+		}
+		c.disk = disk
+	}
+}
+
+// WithMaxEntries bounds the number of entries kept in memory, evicting the
+// least recently used entry once the limit is reached.
+func WithMaxEntries(n int) Option {
+	return func(c *Cache) {
+		c.maxEntries = n
+	}
+}
+
+// WithMaxBytes bounds the total size of the values kept in memory,
+// evicting least recently used entries until the new value fits.
+func WithMaxBytes(n int64) Option {
+	return func(c *Cache) {
+		c.maxBytes = n
+	}
+}
+
+// WithStaleTTL keeps entries in memory for d after they go stale, so
+// Lookup can still hand them out for revalidation.
+func WithStaleTTL(d time.Duration) Option {
+	return func(c *Cache) {
+		c.staleTTL = d
+	}
+}
+
+// NewCache returns a cache whose entries expire after interval, and starts
+// a goroutine that reaps expired entries every interval until Close is
+// called.
+func NewCache(interval time.Duration, opts ...Option) *Cache {
 
 	c := &Cache{
-		store: map[string]CacheEntry{},
-		mutex: sync.Mutex{},
+		store:    map[string]CacheEntry{},
+		clock:    realClock{},
+		interval: interval,
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+		lru:      list.New(),
+		order:    map[string]*list.Element{},
 	}
 
-	ticker := time.NewTicker(interval)
+	for _, opt := range opts {
+		opt(c)
+	}
 
-	go c.reapLoop(interval, ticker.C)
+	tick, stop := c.clock.Tick(interval)
+
+	go c.reapLoop(tick, stop)
 	return c
 }
 
+// Close stops the reaping goroutine and waits for it to exit. The cache
+// remains usable, but expired entries are no longer removed in the
+// background.
+func (c *Cache) Close() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+	})
+	<-c.stopped
+}
+
 func (c *Cache) Add(key string, val []byte) {
+	c.AddWithTTL(key, val, c.interval)
+}
+
+// AddWithTTL stores val under key, expiring it after ttl instead of the
+// cache's default interval.
+func (c *Cache) AddWithTTL(key string, val []byte, ttl time.Duration) {
+	c.add(key, CacheEntry{val: val, ttl: ttl})
+}
+
+// AddWithValidators stores val under key along with the ETag and
+// Last-Modified headers it was served with.
+func (c *Cache) AddWithValidators(key string, val []byte, etag, lastModified string) {
+	c.add(key, CacheEntry{
+		ETag:         etag,
+		LastModified: lastModified,
+		val:          val,
+		ttl:          c.interval,
+	})
+}
+
+func (c *Cache) add(key string, entry CacheEntry) {
+	now := c.clock.Now()
+	entry.CreatedAt = now
+	entry.freshUntil = now.Add(entry.ttl)
+	entry.expiresAt = entry.freshUntil.Add(c.staleTTL)
+
 	c.mutex.Lock()
-	defer c.mutex.Unlock()
-	c.store[key] = CacheEntry{
-		CreatedAt: time.Now(),
-		val:       val,
+	c.set(key, entry)
+	c.mutex.Unlock()
+
+	if c.disk != nil {
+		c.disk.add(key, entry)
 	}
 }
 
 func (c *Cache) Get(key string) ([]byte, bool) {
+	c.mutex.RLock()
 	entry, ok := c.store[key]
+	c.mutex.RUnlock()
+
+	if ok && !c.clock.Now().After(entry.freshUntil) {
+		c.touch(key)
+		c.hits.Add(1)
+		return entry.val, true
+	}
+
+	if c.disk != nil {
+		entry, ok = c.disk.get(key)
+		if ok {
+			// Entries read back from disk count as fresh for another TTL
+			// in memory, so the REPL keeps working offline.
+			c.promote(key, entry, c.clock.Now())
+			c.hits.Add(1)
+			return entry.val, true
+		}
+	}
+
+	c.misses.Add(1)
+	return nil, false
+}
+
+// Lookup returns the entry stored under key even if it has gone stale, and
+// reports whether it is still fresh. Unlike Get, entries read back from
+// disk keep their original age. A stale entry counts as a miss, since the
+// caller still has to fetch the value.
+func (c *Cache) Lookup(key string) (entry CacheEntry, fresh bool, ok bool) {
+	entry, ok = c.lookup(key)
+	if !ok {
+		c.misses.Add(1)
+		return CacheEntry{}, false, false
+	}
+
+	c.touch(key)
+	fresh = !c.clock.Now().After(entry.freshUntil)
+	if fresh {
+		c.hits.Add(1)
+	} else {
+		c.misses.Add(1)
+	}
+	return entry, fresh, true
+}
 
+// Refresh marks the entry stored under key as fresh again, as after a
+// "304 Not Modified" response. It reports whether the key was found.
+func (c *Cache) Refresh(key string) bool {
+	entry, ok := c.lookup(key)
 	if !ok {
-		return nil, false
+		return false
 	}
-	return entry.val, true
+
+	c.add(key, entry)
+	return true
+}
+
+// lookup returns the entry stored under key, in memory or on disk, without
+// counting it as a hit or miss.
+func (c *Cache) lookup(key string) (CacheEntry, bool) {
+	c.mutex.RLock()
+	entry, ok := c.store[key]
+	c.mutex.RUnlock()
+
+	if !ok && c.disk != nil {
+		entry, ok = c.disk.get(key)
+		if ok {
+			entry = c.promote(key, entry, entry.CreatedAt)
+		}
+	}
+	return entry, ok
 }
 
-func (c *Cache) reapLoop(interval time.Duration, timeChan <-chan time.Time) {
+// promote copies an entry read from disk into memory, treating it as
+// fresh from since. It returns the entry as stored.
+func (c *Cache) promote(key string, entry CacheEntry, since time.Time) CacheEntry {
+	if entry.ttl <= 0 {
+		entry.ttl = c.interval
+	}
+	entry.freshUntil = since.Add(entry.ttl)
+	entry.expiresAt = entry.freshUntil.Add(c.staleTTL)
+	if now := c.clock.Now(); entry.expiresAt.Before(now) {
+		entry.expiresAt = now.Add(c.staleTTL)
+	}
+
+	c.mutex.Lock()
+	c.set(key, entry)
+	c.mutex.Unlock()
+
+	return entry
+}
+
+// Stats returns a snapshot of the cache's hit, miss and eviction counters.
+func (c *Cache) Stats() Stats {
+	return Stats{
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Evictions: c.evictions.Load(),
+	}
+}
+
+// Len returns the number of entries held in memory.
+func (c *Cache) Len() int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return len(c.store)
+}
+
+// touch marks key as most recently used. Unbounded caches don't track
+// recency, so their readers never contend for the write lock.
+func (c *Cache) touch(key string) {
+	if c.maxEntries <= 0 && c.maxBytes <= 0 {
+		return
+	}
+
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
-	timeVal := <-timeChan
+	if elem, ok := c.order[key]; ok {
+		c.lru.MoveToFront(elem)
+	}
+}
+
+// set stores entry under key as the most recently used entry and evicts
+// older entries to stay within the configured bounds. c.mutex must be held.
+func (c *Cache) set(key string, entry CacheEntry) {
+	c.remove(key)
+
+	size := int64(len(entry.val))
+	if c.maxBytes > 0 && size > c.maxBytes {
+		return
+	}
+
+	for c.lru.Len() > 0 && c.overBudget(size) {
+		oldest := c.lru.Back().Value.(string)
+		c.remove(oldest)
+		c.evictions.Add(1)
+	}
+
+	c.store[key] = entry
+	c.order[key] = c.lru.PushFront(key)
+	c.size += size
+}
+
+func (c *Cache) overBudget(incoming int64) bool {
+	if c.maxEntries > 0 && len(c.store)+1 > c.maxEntries {
+		return true
+	}
+	return c.maxBytes > 0 && c.size+incoming > c.maxBytes
+}
+
+// remove drops key from memory. c.mutex must be held.
+func (c *Cache) remove(key string) {
+	entry, ok := c.store[key]
+	if !ok {
+		return
+	}
+	c.lru.Remove(c.order[key])
+	delete(c.order, key)
+	delete(c.store, key)
+	c.size -= int64(len(entry.val))
+}
+
+func (c *Cache) reapLoop(timeChan <-chan time.Time, stop func()) {
+	defer close(c.stopped)
+	defer stop()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case timeVal := <-timeChan:
+			c.reap(timeVal)
+		}
+	}
+}
+
+// reap removes every entry that expired before now.
+func (c *Cache) reap(now time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
 
 	for key, val := range c.store {
-		if timeVal.Sub(val.CreatedAt) > interval {
-			delete(c.store, key)
+		if now.After(val.expiresAt) {
+			c.remove(key)
 		}
 	}
 }
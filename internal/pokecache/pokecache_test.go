@@ -2,6 +2,7 @@ package pokecache
 
 import (
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 )
@@ -59,3 +60,228 @@ func TestReapLoop(t *testing.T) {
 		return
 	}
 }
+
+func TestDiskTier(t *testing.T) {
+	const interval = 5 * time.Second
+	dir := t.TempDir()
+
+	first := NewCache(interval, WithDisk(DiskOptions{Dir: dir}))
+	first.Add("https://example.com", []byte("testdata"))
+	createdAt := first.store["https://example.com"].CreatedAt
+
+	second := NewCache(interval, WithDisk(DiskOptions{Dir: dir}))
+	val, ok := second.Get("https://example.com")
+	if !ok {
+		t.Errorf("expected to find key on disk")
+		return
+	}
+	if string(val) != "testdata" {
+		t.Errorf("expected to find value on disk")
+		return
+	}
+	if !second.store["https://example.com"].CreatedAt.Equal(createdAt) {
+		t.Errorf("expected CreatedAt to be preserved")
+	}
+}
+
+func TestDiskTierLimits(t *testing.T) {
+	const interval = 5 * time.Second
+	dir := t.TempDir()
+
+	cache := NewCache(interval, WithDisk(DiskOptions{Dir: dir, MaxEntryBytes: 4}))
+	cache.Add("https://example.com", []byte("testdata"))
+
+	other := NewCache(interval, WithDisk(DiskOptions{Dir: dir}))
+	if _, ok := other.Get("https://example.com"); ok {
+		t.Errorf("expected oversized entry to be kept off disk")
+	}
+}
+
+func TestLRUEviction(t *testing.T) {
+	const interval = 5 * time.Second
+	cache := NewCache(interval, WithMaxEntries(2))
+
+	cache.Add("a", []byte("1"))
+	cache.Add("b", []byte("2"))
+	cache.Get("a")
+	cache.Add("c", []byte("3"))
+
+	if _, ok := cache.Get("b"); ok {
+		t.Errorf("expected least recently used key to be evicted")
+	}
+	if _, ok := cache.Get("a"); !ok {
+		t.Errorf("expected recently used key to be kept")
+	}
+
+	stats := cache.Stats()
+	if stats.Evictions != 1 || stats.Hits != 2 || stats.Misses != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestMaxBytes(t *testing.T) {
+	const interval = 5 * time.Second
+	cache := NewCache(interval, WithMaxBytes(8))
+
+	cache.Add("a", []byte("1234"))
+	cache.Add("b", []byte("5678"))
+	cache.Add("c", []byte("90"))
+
+	if cache.Len() != 2 {
+		t.Errorf("expected 2 entries within the byte budget, got %d", cache.Len())
+	}
+	if _, ok := cache.Get("a"); ok {
+		t.Errorf("expected oldest entry to be evicted")
+	}
+
+	cache.Add("huge", []byte("0123456789"))
+	if _, ok := cache.Get("huge"); ok {
+		t.Errorf("expected value larger than the budget to be rejected")
+	}
+}
+
+type fakeClock struct {
+	mutex sync.Mutex
+	now   time.Time
+	ticks chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{
+		now:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		ticks: make(chan time.Time),
+	}
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.now
+}
+
+func (f *fakeClock) Tick(d time.Duration) (<-chan time.Time, func()) {
+	return f.ticks, func() {}
+}
+
+func (f *fakeClock) Advance(d time.Duration) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.now = f.now.Add(d)
+}
+
+// tick delivers a tick and waits for the reap it triggers to finish; the
+// second send can only be received once the first reap has returned.
+func (f *fakeClock) tick() {
+	f.ticks <- f.Now()
+	f.ticks <- f.Now()
+}
+
+func TestAddWithTTL(t *testing.T) {
+	clock := newFakeClock()
+	cache := NewCache(time.Minute, WithClock(clock))
+	defer cache.Close()
+
+	cache.AddWithTTL("short", []byte("1"), time.Second)
+	cache.Add("long", []byte("2"))
+
+	clock.Advance(2 * time.Second)
+
+	if _, ok := cache.Get("short"); ok {
+		t.Errorf("expected entry past its TTL to be expired")
+	}
+	if _, ok := cache.Get("long"); !ok {
+		t.Errorf("expected entry within the default TTL to be found")
+	}
+
+	clock.tick()
+	if cache.Len() != 1 {
+		t.Errorf("expected expired entry to be reaped, got %d entries", cache.Len())
+	}
+}
+
+func TestReapLoopRunsUntilClose(t *testing.T) {
+	clock := newFakeClock()
+	cache := NewCache(time.Second, WithClock(clock))
+
+	for i := 0; i < 3; i++ {
+		cache.Add("https://example.com", []byte("testdata"))
+		clock.Advance(2 * time.Second)
+		clock.tick()
+		if cache.Len() != 0 {
+			t.Fatalf("expected entry to be reaped on tick %d", i)
+		}
+	}
+
+	cache.Close()
+
+	select {
+	case clock.ticks <- clock.Now():
+		t.Errorf("expected reap loop to stop after Close")
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	clock := newFakeClock()
+	cache := NewCache(time.Second, WithClock(clock), WithMaxEntries(16))
+	defer cache.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				key := fmt.Sprintf("key-%d", (i+j)%32)
+				cache.Add(key, []byte("testdata"))
+				cache.Get(key)
+			}
+		}(i)
+	}
+
+	for i := 0; i < 10; i++ {
+		clock.Advance(500 * time.Millisecond)
+		clock.tick()
+	}
+	wg.Wait()
+
+	if cache.Len() > 16 {
+		t.Errorf("expected at most 16 entries, got %d", cache.Len())
+	}
+}
+
+func TestLookupStaleAndRefresh(t *testing.T) {
+	clock := newFakeClock()
+	cache := NewCache(time.Second, WithClock(clock), WithStaleTTL(time.Minute))
+	defer cache.Close()
+
+	cache.AddWithValidators("https://example.com", []byte("testdata"), `"abc"`, "")
+
+	clock.Advance(2 * time.Second)
+	clock.tick()
+
+	if _, ok := cache.Get("https://example.com"); ok {
+		t.Errorf("expected Get to skip a stale entry")
+	}
+
+	entry, fresh, ok := cache.Lookup("https://example.com")
+	if !ok || fresh {
+		t.Fatalf("expected Lookup to return the stale entry, got ok=%v fresh=%v", ok, fresh)
+	}
+	if entry.ETag != `"abc"` || string(entry.Value()) != "testdata" {
+		t.Errorf("expected the stale entry to keep its value and ETag")
+	}
+
+	if !cache.Refresh("https://example.com") {
+		t.Fatalf("expected Refresh to find the entry")
+	}
+	if _, ok := cache.Get("https://example.com"); !ok {
+		t.Errorf("expected a refreshed entry to be fresh again")
+	}
+
+	// The stale Get and Lookup both had to go to the network; Refresh is
+	// not a lookup of its own.
+	if stats := cache.Stats(); stats.Hits != 1 || stats.Misses != 2 {
+		t.Errorf("expected 1 hit and 2 misses, got %+v", stats)
+	}
+}
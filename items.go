@@ -0,0 +1,179 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/chandanbsd/pokedex/internal/model"
+	"github.com/chandanbsd/pokedex/internal/pokeapi"
+)
+
+// What a new player starts with.
+const (
+	startingMoney = 3000
+	startingBalls = 10
+)
+
+// catchRewardRate is how many Poke Dollars a catch earns for each point of
+// the Pokemon's base experience.
+const catchRewardRate = 5
+
+// The player's items, counted by PokeAPI item name, and their Poke
+// Dollars.
+var (
+	inventory = startingInventory()
+	money     = startingMoney
+)
+
+func startingInventory() map[string]int {
+	return map[string]int{"poke-ball": startingBalls}
+}
+
+func inventoryNames() []string {
+	return sortedKeys(inventory)
+}
+
+func commandItems(args commandArgs) error {
+	fmt.Printf("Money: %d\n", money)
+	fmt.Println("Items:")
+	for _, name := range inventoryNames() {
+		fmt.Printf(" - %s x%d\n", name, inventory[name])
+	}
+	return nil
+}
+
+func commandBuy(args commandArgs) error {
+	itemName := args.arg(0)
+
+	quantity := 1
+	if value := args.arg(1); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return fmt.Errorf("quantity must be a positive number, got %q", value)
+		}
+		quantity = n
+	}
+
+	item, err := client.GetItem(itemName)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return fmt.Errorf("no item named %s", itemName)
+	}
+	if err != nil {
+		return apiError(err)
+	}
+
+	if item.Cost == 0 {
+		return fmt.Errorf("%s is not for sale", item.Name)
+	}
+	// Compare before multiplying, so a huge quantity cannot overflow the
+	// total into a refund.
+	if quantity > money/item.Cost {
+		return fmt.Errorf("you can afford %d %s at %d each, you only have %d", money/item.Cost, item.Name, item.Cost, money)
+	}
+	total := item.Cost * quantity
+
+	money -= total
+	inventory[item.Name] += quantity
+	fmt.Printf("Bought %d %s for %d, %d left\n", quantity, item.Name, total, money)
+	return nil
+}
+
+// commandUse uses an item on a caught Pokemon. Evolution stones evolve
+// Pokemon whose evolution chain calls for them; the item is only used up
+// when it has an effect.
+func commandUse(args commandArgs) error {
+	itemName, pokemonName := args.arg(0), args.arg(1)
+
+	if inventory[itemName] == 0 {
+		return fmt.Errorf("you have no %s", itemName)
+	}
+	caught, err := bag.find(pokemonName)
+	if err != nil {
+		return err
+	}
+
+	item, err := client.GetItem(itemName)
+	if err != nil {
+		return apiError(err)
+	}
+	if item.Category.Name != "evolution" {
+		return fmt.Errorf("%s has no effect on %s", itemName, pokemonName)
+	}
+
+	pokemon, err := caught.pokemon()
+	if err != nil {
+		return err
+	}
+	target, err := itemEvolution(pokemon, itemName)
+	if err != nil {
+		return err
+	}
+	if target == "" {
+		return fmt.Errorf("%s has no effect on %s", itemName, pokemonName)
+	}
+
+	evolved, err := client.GetPokemon(target)
+	if err != nil {
+		return apiError(err)
+	}
+
+	useItem(itemName)
+	name := caught.name()
+	caught.Species = evolved.Name
+	seenPokemon[evolved.Name] = true
+	fmt.Printf("%s evolved into %s!\n", name, evolved.Name)
+	return nil
+}
+
+// itemEvolution returns the species pokemon evolves into when the named
+// item is used on it, or "" if it does not evolve that way.
+func itemEvolution(pokemon model.Pokemon, itemName string) (string, error) {
+	species, err := pokeapi.Fetch[model.PokemonSpecies](client, pokemon.Species.URL)
+	if err != nil {
+		return "", apiError(err)
+	}
+	if species.EvolutionChain == nil {
+		return "", nil
+	}
+
+	chain, err := pokeapi.Fetch[model.EvolutionChain](client, species.EvolutionChain.URL)
+	if err != nil {
+		return "", apiError(err)
+	}
+
+	link, ok := findChainLink(chain.Chain, species.Name)
+	if !ok {
+		return "", nil
+	}
+	for _, next := range link.EvolvesTo {
+		for _, detail := range next.EvolutionDetails {
+			if detail.Trigger.Name == "use-item" && detail.Item != nil && detail.Item.Name == itemName {
+				return next.Species.Name, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// findChainLink finds the link for the named species in the tree rooted at
+// link.
+func findChainLink(link model.ChainLink, species string) (model.ChainLink, bool) {
+	if link.Species.Name == species {
+		return link, true
+	}
+	for _, next := range link.EvolvesTo {
+		if found, ok := findChainLink(next, species); ok {
+			return found, true
+		}
+	}
+	return model.ChainLink{}, false
+}
+
+// useItem takes one of the named item out of the inventory.
+func useItem(name string) {
+	inventory[name]--
+	if inventory[name] <= 0 {
+		delete(inventory, name)
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestBuy(t *testing.T) {
+	useCassette(t, "testdata/session.json")
+	inventory, money = map[string]int{}, 1000
+	t.Cleanup(func() { inventory, money = startingInventory(), startingMoney })
+
+	if err := runCommand("buy poke-ball 2"); err != nil {
+		t.Fatalf("buy: %v", err)
+	}
+	if inventory["poke-ball"] != 2 || money != 600 {
+		t.Errorf("expected 2 poke-balls and 600 left, got %v and %d", inventory, money)
+	}
+
+	cases := []struct {
+		command  string
+		expected string
+	}{
+		{"buy thunder-stone", "you can afford 0 thunder-stone at 3000 each, you only have 600"},
+		{"buy poke-ball 4", "you can afford 3 poke-ball at 200 each, you only have 600"},
+		{"buy poke-ball 92233720368547758", "you can afford 3 poke-ball at 200 each, you only have 600"},
+		{"buy master-ball", "master-ball is not for sale"},
+		{"buy bogus", "no item named bogus"},
+		{"buy poke-ball none", `quantity must be a positive number, got "none"`},
+		{"buy poke-ball 0", `quantity must be a positive number, got "0"`},
+	}
+	for _, c := range cases {
+		if err := runCommand(c.command); err == nil || err.Error() != c.expected {
+			t.Errorf("%s: expected %q, got %v", c.command, c.expected, err)
+		}
+	}
+	if money != 600 {
+		t.Errorf("expected failed purchases to cost nothing, have %d", money)
+	}
+}
+
+func TestUseEvolutionStone(t *testing.T) {
+	useCassette(t, "testdata/session.json")
+	inventory = map[string]int{"thunder-stone": 1, "water-stone": 1, "potion": 1}
+	bag = storageOf("pikachu", "eevee")
+	t.Cleanup(func() {
+		inventory, bag = startingInventory(), newPokemonStorage()
+	})
+
+	if err := runCommand("use potion pikachu"); err == nil || err.Error() != "potion has no effect on pikachu" {
+		t.Errorf("expected a potion to have no effect, got %v", err)
+	}
+	if err := runCommand("use water-stone pikachu"); err == nil || err.Error() != "water-stone has no effect on pikachu" {
+		t.Errorf("expected a water stone not to evolve pikachu, got %v", err)
+	}
+	if err := runCommand("use fire-stone eevee"); err == nil || err.Error() != "you have no fire-stone" {
+		t.Errorf("expected to need a fire stone, got %v", err)
+	}
+
+	if err := runCommand("use thunder-stone pikachu"); err != nil {
+		t.Fatalf("use: %v", err)
+	}
+	if err := runCommand("use water-stone eevee"); err != nil {
+		t.Fatalf("use: %v", err)
+	}
+
+	if !slices.Equal(bag.species(), []string{"raichu", "vaporeon"}) {
+		t.Errorf("expected pikachu and eevee to have evolved, have %v", bag.species())
+	}
+	if !maps.Equal(map[string]int{"potion": 1}, inventory) {
+		t.Errorf("expected the stones to be used up, have %v", inventory)
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/chandanbsd/pokedex/internal/model"
+	"github.com/chandanbsd/pokedex/internal/pokeapi"
+)
+
+// Where the player is: the location area they are in, and the game
+// version whose encounter tables apply there. An empty gameVersion allows
+// the encounters of every version.
+var (
+	currentArea *model.LocationArea
+	gameVersion string
+)
+
+func commandGoto(args commandArgs) error {
+	areaName := args.arg(0)
+
+	area, err := client.GetLocationArea(areaName)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return fmt.Errorf("no location area named %s", areaName)
+	}
+	if err != nil {
+		return apiError(err)
+	}
+
+	moveTo(area)
+	fmt.Printf("You are now in %s\n", area.Name)
+	return nil
+}
+
+func moveTo(area model.LocationArea) {
+	currentArea = &area
+	seenLocationAreas[area.Name] = true
+}
+
+func commandVersion(args commandArgs) error {
+	switch version := args.arg(0); version {
+	case "":
+		if gameVersion == "" {
+			fmt.Println("Playing every game version")
+		} else {
+			fmt.Printf("Playing %s\n", gameVersion)
+		}
+	case "any":
+		gameVersion = ""
+		fmt.Println("Playing every game version")
+	default:
+		gameVersion = version
+		fmt.Printf("Playing %s\n", gameVersion)
+	}
+	return nil
+}
+
+// encounterChance returns the best chance, in percent, of meeting the
+// named Pokemon in the current area in the active game version.
+func encounterChance(pokemon string) (int, error) {
+	if currentArea == nil {
+		return 0, errors.New("you are not in any location area, use goto or explore to go to one")
+	}
+
+	chance := 0
+	for _, row := range encounterRows(*currentArea, encounterFilter{version: gameVersion}) {
+		if row.pokemon == pokemon {
+			chance = max(chance, row.chance)
+		}
+	}
+	if chance == 0 {
+		if gameVersion != "" {
+			return 0, fmt.Errorf("%s cannot be found in %s in %s", pokemon, currentArea.Name, gameVersion)
+		}
+		return 0, fmt.Errorf("%s cannot be found in %s", pokemon, currentArea.Name)
+	}
+	return min(chance, 100), nil
+}
+
+// localPokemonNames lists the Pokemon that can be met in the current
+// area, for tab completion.
+func localPokemonNames() []string {
+	if currentArea == nil {
+		return nil
+	}
+
+	names := map[string]bool{}
+	for _, row := range encounterRows(*currentArea, encounterFilter{version: gameVersion}) {
+		names[row.pokemon] = true
+	}
+	return sortedKeys(names)
+}
+
+// encounterLevels returns the range of levels the named Pokemon is met at
+// in the current area in the active game version.
+func encounterLevels(pokemon string) (minLevel, maxLevel int) {
+	for _, row := range encounterRows(*currentArea, encounterFilter{version: gameVersion}) {
+		if row.pokemon != pokemon {
+			continue
+		}
+		if minLevel == 0 || row.minLevel < minLevel {
+			minLevel = row.minLevel
+		}
+		maxLevel = max(maxLevel, row.maxLevel)
+	}
+	return max(minLevel, 1), max(maxLevel, minLevel, 1)
+}
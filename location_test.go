@@ -0,0 +1,74 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/chandanbsd/pokedex/internal/model"
+)
+
+func TestEncounterChance(t *testing.T) {
+	currentArea, gameVersion = nil, ""
+	t.Cleanup(func() { currentArea, gameVersion = nil, "" })
+
+	if _, err := encounterChance("magikarp"); err == nil {
+		t.Errorf("expected an error before going anywhere")
+	}
+
+	moveTo(loadFixture[model.LocationArea](t, "fixtures/pokeapi/location-area/viridian-forest-area.json"))
+
+	if chance, err := encounterChance("pikachu"); err != nil || chance != 10 {
+		t.Errorf("expected the best chance across versions, 10%%, got %d%% (%v)", chance, err)
+	}
+
+	gameVersion = "red"
+	if chance, err := encounterChance("pikachu"); err != nil || chance != 5 {
+		t.Errorf("expected 5%% in red, got %d%% (%v)", chance, err)
+	}
+
+	gameVersion = "diamond"
+	if _, err := encounterChance("pikachu"); err == nil || err.Error() != "pikachu cannot be found in viridian-forest-area in diamond" {
+		t.Errorf("expected pikachu to be missing from diamond, got %v", err)
+	}
+	if names := localPokemonNames(); len(names) != 0 {
+		t.Errorf("expected nothing to complete in diamond, got %v", names)
+	}
+
+	gameVersion = ""
+	if _, err := encounterChance("magikarp"); err == nil {
+		t.Errorf("expected magikarp to be missing from the forest")
+	}
+}
+
+func TestGotoAndCatch(t *testing.T) {
+	useCassette(t, "testdata/session.json")
+	currentArea, gameVersion = nil, ""
+	t.Cleanup(func() { currentArea, gameVersion = nil, "" })
+
+	if err := runCommand("catch gyarados"); err == nil {
+		t.Errorf("expected catching outside a location area to fail")
+	}
+
+	if err := runCommand("goto canalave-city-area"); err != nil {
+		t.Fatalf("goto: %v", err)
+	}
+	if currentArea == nil || currentArea.Name != "canalave-city-area" {
+		t.Fatalf("expected to be in canalave-city-area")
+	}
+
+	inventory = map[string]int{}
+	t.Cleanup(func() { inventory = startingInventory() })
+	if err := runCommand("catch gyarados"); err == nil || err.Error() != "you have no Poke Balls left, buy some with buy poke-ball" {
+		t.Errorf("expected to need a Poke Ball, got %v", err)
+	}
+
+	inventory["poke-ball"] = 1
+	if err := runCommand("catch gyarados"); err != nil {
+		t.Errorf("catch: %v", err)
+	}
+	if err := runCommand("catch pikachu"); err == nil || err.Error() != "pikachu cannot be found in canalave-city-area" {
+		t.Errorf("expected pikachu to be missing from the area, got %v", err)
+	}
+	if err := runCommand("goto nowhere-area"); err == nil {
+		t.Errorf("expected an unknown area to fail")
+	}
+}
@@ -1,29 +1,42 @@
 package main
 
 import (
-	"bufio"
-	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
-	"io"
+	"io/fs"
 	"math/rand"
-	"net/http"
 	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/chandanbsd/pokedex/internal/cassette"
+	"github.com/chandanbsd/pokedex/internal/model"
+	"github.com/chandanbsd/pokedex/internal/pokeapi"
 	"github.com/chandanbsd/pokedex/internal/pokecache"
 )
 
 type cliCommand struct {
 	name        string
 	description string
-	callback    func(string) error
+	args        []argSpec
+	flags       []flagSpec
+	callback    func(commandArgs) error
 	config      *config
 }
 
+// config is the state commands share: which pages of location areas map
+// and mapb show next, and the random source catches draw from.
 type config struct {
-	Next     *string
-	Previous *string
+	Next     *int
+	Previous *int
+	// seed is what rand was last seeded with.
+	seed int64
+	rand *rand.Rand
 }
 
 var c *config = &config{
@@ -36,356 +49,44 @@ type result struct {
 	Url  string `json:"url"`
 }
 
-type locationArea struct {
-	Count    int     `json:"count"`
-	Next     *string `json:"next"`
-	Previous *string `json:"previous"`
-	Results  []struct {
-		Name string `json:"name"`
-		URL  string `json:"url"`
-	} `json:"results"`
-}
+var commands map[string]cliCommand
 
-type LocationAreaPokemon struct {
-	EncounterMethodRates []struct {
-		EncounterMethod struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"encounter_method"`
-		VersionDetails []struct {
-			Rate    int `json:"rate"`
-			Version struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"version"`
-		} `json:"version_details"`
-	} `json:"encounter_method_rates"`
-	GameIndex int `json:"game_index"`
-	ID        int `json:"id"`
-	Location  struct {
-		Name string `json:"name"`
-		URL  string `json:"url"`
-	} `json:"location"`
-	Name  string `json:"name"`
-	Names []struct {
-		Language struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"language"`
-		Name string `json:"name"`
-	} `json:"names"`
-	PokemonEncounters []struct {
-		Pokemon struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"pokemon"`
-		VersionDetails []struct {
-			EncounterDetails []struct {
-				Chance          int           `json:"chance"`
-				ConditionValues []interface{} `json:"condition_values"`
-				MaxLevel        int           `json:"max_level"`
-				Method          struct {
-					Name string `json:"name"`
-					URL  string `json:"url"`
-				} `json:"method"`
-				MinLevel int `json:"min_level"`
-			} `json:"encounter_details"`
-			MaxChance int `json:"max_chance"`
-			Version   struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"version"`
-		} `json:"version_details"`
-	} `json:"pokemon_encounters"`
-}
+var cache *pokecache.Cache
 
-type Pokemon struct {
-	Abilities []struct {
-		Ability struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"ability"`
-		IsHidden bool `json:"is_hidden"`
-		Slot     int  `json:"slot"`
-	} `json:"abilities"`
-	BaseExperience int `json:"base_experience"`
-	Cries          struct {
-		Latest string `json:"latest"`
-		Legacy string `json:"legacy"`
-	} `json:"cries"`
-	Forms []struct {
-		Name string `json:"name"`
-		URL  string `json:"url"`
-	} `json:"forms"`
-	GameIndices []struct {
-		GameIndex int `json:"game_index"`
-		Version   struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"version"`
-	} `json:"game_indices"`
-	Height    int `json:"height"`
-	HeldItems []struct {
-		Item struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"item"`
-		VersionDetails []struct {
-			Rarity  int `json:"rarity"`
-			Version struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"version"`
-		} `json:"version_details"`
-	} `json:"held_items"`
-	ID                     int    `json:"id"`
-	IsDefault              bool   `json:"is_default"`
-	LocationAreaEncounters string `json:"location_area_encounters"`
-	Moves                  []struct {
-		Move struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"move"`
-		VersionGroupDetails []struct {
-			LevelLearnedAt  int `json:"level_learned_at"`
-			MoveLearnMethod struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"move_learn_method"`
-			Order        interface{} `json:"order"`
-			VersionGroup struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"version_group"`
-		} `json:"version_group_details"`
-	} `json:"moves"`
-	Name          string `json:"name"`
-	Order         int    `json:"order"`
-	PastAbilities []struct {
-		Abilities []struct {
-			Ability  interface{} `json:"ability"`
-			IsHidden bool        `json:"is_hidden"`
-			Slot     int         `json:"slot"`
-		} `json:"abilities"`
-		Generation struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"generation"`
-	} `json:"past_abilities"`
-	PastTypes []interface{} `json:"past_types"`
-	Species   struct {
-		Name string `json:"name"`
-		URL  string `json:"url"`
-	} `json:"species"`
-	Sprites struct {
-		BackDefault      string `json:"back_default"`
-		BackFemale       string `json:"back_female"`
-		BackShiny        string `json:"back_shiny"`
-		BackShinyFemale  string `json:"back_shiny_female"`
-		FrontDefault     string `json:"front_default"`
-		FrontFemale      string `json:"front_female"`
-		FrontShiny       string `json:"front_shiny"`
-		FrontShinyFemale string `json:"front_shiny_female"`
-		Other            struct {
-			DreamWorld struct {
-				FrontDefault string      `json:"front_default"`
-				FrontFemale  interface{} `json:"front_female"`
-			} `json:"dream_world"`
-			Home struct {
-				FrontDefault     string `json:"front_default"`
-				FrontFemale      string `json:"front_female"`
-				FrontShiny       string `json:"front_shiny"`
-				FrontShinyFemale string `json:"front_shiny_female"`
-			} `json:"home"`
-			OfficialArtwork struct {
-				FrontDefault string `json:"front_default"`
-				FrontShiny   string `json:"front_shiny"`
-			} `json:"official-artwork"`
-			Showdown struct {
-				BackDefault      string      `json:"back_default"`
-				BackFemale       string      `json:"back_female"`
-				BackShiny        string      `json:"back_shiny"`
-				BackShinyFemale  interface{} `json:"back_shiny_female"`
-				FrontDefault     string      `json:"front_default"`
-				FrontFemale      string      `json:"front_female"`
-				FrontShiny       string      `json:"front_shiny"`
-				FrontShinyFemale string      `json:"front_shiny_female"`
-			} `json:"showdown"`
-		} `json:"other"`
-		Versions struct {
-			GenerationI struct {
-				RedBlue struct {
-					BackDefault      string `json:"back_default"`
-					BackGray         string `json:"back_gray"`
-					BackTransparent  string `json:"back_transparent"`
-					FrontDefault     string `json:"front_default"`
-					FrontGray        string `json:"front_gray"`
-					FrontTransparent string `json:"front_transparent"`
-				} `json:"red-blue"`
-				Yellow struct {
-					BackDefault      string `json:"back_default"`
-					BackGray         string `json:"back_gray"`
-					BackTransparent  string `json:"back_transparent"`
-					FrontDefault     string `json:"front_default"`
-					FrontGray        string `json:"front_gray"`
-					FrontTransparent string `json:"front_transparent"`
-				} `json:"yellow"`
-			} `json:"generation-i"`
-			GenerationIi struct {
-				Crystal struct {
-					BackDefault           string `json:"back_default"`
-					BackShiny             string `json:"back_shiny"`
-					BackShinyTransparent  string `json:"back_shiny_transparent"`
-					BackTransparent       string `json:"back_transparent"`
-					FrontDefault          string `json:"front_default"`
-					FrontShiny            string `json:"front_shiny"`
-					FrontShinyTransparent string `json:"front_shiny_transparent"`
-					FrontTransparent      string `json:"front_transparent"`
-				} `json:"crystal"`
-				Gold struct {
-					BackDefault      string `json:"back_default"`
-					BackShiny        string `json:"back_shiny"`
-					FrontDefault     string `json:"front_default"`
-					FrontShiny       string `json:"front_shiny"`
-					FrontTransparent string `json:"front_transparent"`
-				} `json:"gold"`
-				Silver struct {
-					BackDefault      string `json:"back_default"`
-					BackShiny        string `json:"back_shiny"`
-					FrontDefault     string `json:"front_default"`
-					FrontShiny       string `json:"front_shiny"`
-					FrontTransparent string `json:"front_transparent"`
-				} `json:"silver"`
-			} `json:"generation-ii"`
-			GenerationIii struct {
-				Emerald struct {
-					FrontDefault string `json:"front_default"`
-					FrontShiny   string `json:"front_shiny"`
-				} `json:"emerald"`
-				FireredLeafgreen struct {
-					BackDefault  string `json:"back_default"`
-					BackShiny    string `json:"back_shiny"`
-					FrontDefault string `json:"front_default"`
-					FrontShiny   string `json:"front_shiny"`
-				} `json:"firered-leafgreen"`
-				RubySapphire struct {
-					BackDefault  string `json:"back_default"`
-					BackShiny    string `json:"back_shiny"`
-					FrontDefault string `json:"front_default"`
-					FrontShiny   string `json:"front_shiny"`
-				} `json:"ruby-sapphire"`
-			} `json:"generation-iii"`
-			GenerationIv struct {
-				DiamondPearl struct {
-					BackDefault      string `json:"back_default"`
-					BackFemale       string `json:"back_female"`
-					BackShiny        string `json:"back_shiny"`
-					BackShinyFemale  string `json:"back_shiny_female"`
-					FrontDefault     string `json:"front_default"`
-					FrontFemale      string `json:"front_female"`
-					FrontShiny       string `json:"front_shiny"`
-					FrontShinyFemale string `json:"front_shiny_female"`
-				} `json:"diamond-pearl"`
-				HeartgoldSoulsilver struct {
-					BackDefault      string `json:"back_default"`
-					BackFemale       string `json:"back_female"`
-					BackShiny        string `json:"back_shiny"`
-					BackShinyFemale  string `json:"back_shiny_female"`
-					FrontDefault     string `json:"front_default"`
-					FrontFemale      string `json:"front_female"`
-					FrontShiny       string `json:"front_shiny"`
-					FrontShinyFemale string `json:"front_shiny_female"`
-				} `json:"heartgold-soulsilver"`
-				Platinum struct {
-					BackDefault      string `json:"back_default"`
-					BackFemale       string `json:"back_female"`
-					BackShiny        string `json:"back_shiny"`
-					BackShinyFemale  string `json:"back_shiny_female"`
-					FrontDefault     string `json:"front_default"`
-					FrontFemale      string `json:"front_female"`
-					FrontShiny       string `json:"front_shiny"`
-					FrontShinyFemale string `json:"front_shiny_female"`
-				} `json:"platinum"`
-			} `json:"generation-iv"`
-			GenerationV struct {
-				BlackWhite struct {
-					Animated struct {
-						BackDefault      string `json:"back_default"`
-						BackFemale       string `json:"back_female"`
-						BackShiny        string `json:"back_shiny"`
-						BackShinyFemale  string `json:"back_shiny_female"`
-						FrontDefault     string `json:"front_default"`
-						FrontFemale      string `json:"front_female"`
-						FrontShiny       string `json:"front_shiny"`
-						FrontShinyFemale string `json:"front_shiny_female"`
-					} `json:"animated"`
-					BackDefault      string `json:"back_default"`
-					BackFemale       string `json:"back_female"`
-					BackShiny        string `json:"back_shiny"`
-					BackShinyFemale  string `json:"back_shiny_female"`
-					FrontDefault     string `json:"front_default"`
-					FrontFemale      string `json:"front_female"`
-					FrontShiny       string `json:"front_shiny"`
-					FrontShinyFemale string `json:"front_shiny_female"`
-				} `json:"black-white"`
-			} `json:"generation-v"`
-			GenerationVi struct {
-				OmegarubyAlphasapphire struct {
-					FrontDefault     string `json:"front_default"`
-					FrontFemale      string `json:"front_female"`
-					FrontShiny       string `json:"front_shiny"`
-					FrontShinyFemale string `json:"front_shiny_female"`
-				} `json:"omegaruby-alphasapphire"`
-				XY struct {
-					FrontDefault     string `json:"front_default"`
-					FrontFemale      string `json:"front_female"`
-					FrontShiny       string `json:"front_shiny"`
-					FrontShinyFemale string `json:"front_shiny_female"`
-				} `json:"x-y"`
-			} `json:"generation-vi"`
-			GenerationVii struct {
-				Icons struct {
-					FrontDefault string      `json:"front_default"`
-					FrontFemale  interface{} `json:"front_female"`
-				} `json:"icons"`
-				UltraSunUltraMoon struct {
-					FrontDefault     string `json:"front_default"`
-					FrontFemale      string `json:"front_female"`
-					FrontShiny       string `json:"front_shiny"`
-					FrontShinyFemale string `json:"front_shiny_female"`
-				} `json:"ultra-sun-ultra-moon"`
-			} `json:"generation-vii"`
-			GenerationViii struct {
-				Icons struct {
-					FrontDefault string `json:"front_default"`
-					FrontFemale  string `json:"front_female"`
-				} `json:"icons"`
-			} `json:"generation-viii"`
-		} `json:"versions"`
-	} `json:"sprites"`
-	Stats []struct {
-		BaseStat int `json:"base_stat"`
-		Effort   int `json:"effort"`
-		Stat     struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"stat"`
-	} `json:"stats"`
-	Types []struct {
-		Slot int `json:"slot"`
-		Type struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"type"`
-	} `json:"types"`
-	Weight int `json:"weight"`
-}
+var client *pokeapi.Client
 
-var bag map[string]Pokemon = map[string]Pokemon{}
+// cacheTTL is how long a PokeAPI response is served from memory before it
+// is revalidated. PokeAPI data changes rarely, so hours are safe.
+const cacheTTL = 6 * time.Hour
 
-var commands map[string]cliCommand
+// newCache returns the cache for PokeAPI responses. The on-disk tier is
+// only added when persistent is set; recording and replaying leave it out
+// so that every request reaches the cassette.
+func newCache(persistent bool) *pokecache.Cache {
+	opts := []pokecache.Option{
+		pokecache.WithMaxEntries(500),
+		pokecache.WithMaxBytes(32 << 20),
+		pokecache.WithStaleTTL(24 * time.Hour),
+	}
 
-var cache *pokecache.Cache = pokecache.NewCache(5 * time.Millisecond)
+	if persistent {
+		opts = append(opts, pokecache.WithDisk(pokecache.DiskOptions{
+			Dir:           cacheDir(),
+			MaxBytes:      64 << 20,
+			MaxEntryBytes: 4 << 20,
+		}))
+	}
+
+	return pokecache.NewCache(cacheTTL, opts...)
+}
+
+func cacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "pokedex", "http")
+}
 
 func init() {
 	commands = map[string]cliCommand{
@@ -404,13 +105,115 @@ func init() {
 		"explore": {
 			name:        "explore",
 			description: "Used to explore the pokemons at the given location",
-			callback:    commandExplore,
+			args:        []argSpec{{name: "location-area", complete: locationAreaNames}},
+			flags: slices.Concat(encounterFilterFlags, []flagSpec{
+				{name: "details", description: "show encounter rates and a table of chances and levels", boolean: true},
+			}),
+			callback: commandExplore,
+			config:   c,
+		},
+		"where": {
+			name:        "where",
+			description: "Lists the location areas where a pokemon can be found",
+			args:        []argSpec{{name: "pokemon", complete: pokemonNames}},
+			flags:       encounterFilterFlags,
+			callback:    commandWhere,
+			config:      c,
+		},
+		"goto": {
+			name:        "goto",
+			description: "Goes to a location area, where its pokemon can be caught",
+			args:        []argSpec{{name: "location-area", complete: locationAreaNames}},
+			callback:    commandGoto,
+			config:      c,
+		},
+		"version": {
+			name:        "version",
+			description: "Shows or sets the game version whose encounters apply, or any",
+			args:        []argSpec{{name: "version", optional: true}},
+			callback:    commandVersion,
 			config:      c,
 		},
 		"catch": {
 			name:        "catch",
 			description: "catches a pokemon",
-			callback:    commandCatch,
+			args:        []argSpec{{name: "pokemon", complete: localPokemonNames}},
+			flags: []flagSpec{
+				{name: "ball", description: "the ball to throw: poke, great, ultra or master"},
+				{name: "hp", description: "the target's remaining HP in percent, lower is easier to catch"},
+				{name: "status", description: "the target's status: sleep, freeze, paralysis, poison or burn"},
+			},
+			callback: commandCatch,
+			config:   c,
+		},
+		"items": {
+			name:        "items",
+			description: "Lists your items and money",
+			callback:    commandItems,
+			config:      c,
+		},
+		"buy": {
+			name:        "buy",
+			description: "Buys items, such as poke-ball or thunder-stone",
+			args: []argSpec{
+				{name: "item"},
+				{name: "quantity", optional: true},
+			},
+			callback: commandBuy,
+			config:   c,
+		},
+		"use": {
+			name:        "use",
+			description: "Uses an item on a caught pokemon, such as an evolution stone",
+			args: []argSpec{
+				{name: "item", complete: inventoryNames},
+				{name: "pokemon", complete: caughtNames},
+			},
+			callback: commandUse,
+			config:   c,
+		},
+		"party": {
+			name:        "party",
+			description: "Lists the pokemon in your party",
+			callback:    commandParty,
+			config:      c,
+		},
+		"box": {
+			name:        "box",
+			description: "Lists the pokemon in your PC boxes",
+			args:        []argSpec{{name: "box", optional: true}},
+			callback:    commandBox,
+			config:      c,
+		},
+		"deposit": {
+			name:        "deposit",
+			description: "Sends a pokemon from your party to a PC box",
+			args:        []argSpec{{name: "pokemon", complete: caughtNames}},
+			callback:    commandDeposit,
+			config:      c,
+		},
+		"withdraw": {
+			name:        "withdraw",
+			description: "Brings a pokemon from a PC box into your party",
+			args:        []argSpec{{name: "pokemon", complete: caughtNames}},
+			callback:    commandWithdraw,
+			config:      c,
+		},
+		"nickname": {
+			name:        "nickname",
+			description: "Gives a caught pokemon a nickname, or clears it",
+			args: []argSpec{
+				{name: "pokemon", complete: caughtNames},
+				{name: "nickname", optional: true, keepCase: true},
+			},
+			callback: commandNickname,
+			config:   c,
+		},
+		"release": {
+			name:        "release",
+			description: "Releases a caught pokemon",
+			args:        []argSpec{{name: "pokemon", complete: caughtNames}},
+			callback:    commandRelease,
 			config:      c,
 		},
 		"pokedex": {
@@ -422,15 +225,83 @@ func init() {
 		"inspect": {
 			name:        "inspect",
 			description: "inspect the pokemon",
-			callback:    commandInspect,
+			args:        []argSpec{{name: "pokemon", variadic: true, complete: caughtNames}},
+			flags: []flagSpec{
+				{name: "level", description: "compute stats at this level instead of the pokemon's own"},
+			},
+			callback: commandInspect,
+			config:   c,
+		},
+		"evolution": {
+			name:        "evolution",
+			description: "Shows the evolution chain of a pokemon",
+			args:        []argSpec{{name: "pokemon", complete: pokemonNames}},
+			callback:    commandEvolution,
+			config:      c,
+		},
+		"matchup": {
+			name:        "matchup",
+			description: "Shows how effective an attacker's types are against a defender",
+			args: []argSpec{
+				{name: "attacker", complete: pokemonOrTypeNames},
+				{name: "defender", complete: pokemonOrTypeNames},
+			},
+			callback: commandMatchup,
+			config:   c,
+		},
+		"weaknesses": {
+			name:        "weaknesses",
+			description: "Shows how much damage a pokemon takes from each type",
+			args:        []argSpec{{name: "pokemon", complete: pokemonOrTypeNames}},
+			callback:    commandWeaknesses,
+			config:      c,
+		},
+		"moves": {
+			name:        "moves",
+			description: "Lists the moves a caught pokemon can learn",
+			args:        []argSpec{{name: "pokemon", complete: caughtNames}},
+			flags: []flagSpec{
+				{name: "version", description: "only moves learned in this version or version group, such as red or red-blue"},
+				{name: "method", description: "only moves learned this way: level-up, machine, egg or tutor"},
+				{name: "details", description: "also fetch each move's type, power, accuracy and PP", boolean: true},
+			},
+			callback: commandMoves,
+			config:   c,
+		},
+		"save": {
+			name:        "save",
+			description: "Saves the Pokedex to disk",
+			args:        []argSpec{{name: "path", optional: true, keepCase: true}},
+			callback:    commandSave,
+			config:      c,
+		},
+		"load": {
+			name:        "load",
+			description: "Loads the Pokedex from disk",
+			args:        []argSpec{{name: "path", optional: true, keepCase: true}},
+			callback:    commandLoad,
+			config:      c,
+		},
+		"cache": {
+			name:        "cache",
+			description: "Shows cache statistics",
+			callback:    commandCache,
 			config:      c,
 		},
 		"help": {
 			name:        "help",
 			description: "Displays a help message",
+			args:        []argSpec{{name: "command", optional: true, complete: commandNames}},
 			callback:    commandHelp,
 			config:      c,
 		},
+		"seed": {
+			name:        "seed",
+			description: "Shows or sets the seed catches are drawn from",
+			args:        []argSpec{{name: "seed", optional: true}},
+			callback:    commandSeed,
+			config:      c,
+		},
 		"exit": {
 			name:        "exit",
 			description: "Exit the Pokedex",
@@ -441,309 +312,384 @@ func init() {
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		if err := runServe(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
 
-	var word string
-	scanner := bufio.NewScanner(os.Stdin)
+	apiURL := flag.String("api-url", envOr("POKEDEX_API_URL", pokeapi.DefaultBaseURL),
+		"base URL of the PokeAPI to use (env POKEDEX_API_URL)")
+	staleWhileRevalidate := flag.Bool("stale-while-revalidate", false,
+		"serve stale cached responses while revalidating them in the background")
+	recordPath := flag.String("record", "", "record every PokeAPI request and response to this cassette file")
+	replayPath := flag.String("replay", "", "serve PokeAPI responses from this cassette file instead of the network")
+	flag.StringVar(&gameVersion, "game-version", "", "game version whose encounter tables apply, such as red or diamond")
+	seed := flag.Int64("seed", 0, "seed for the random source catches draw from, to replay a session exactly")
+	script := flag.String("c", "", "run these commands, separated by semicolons, then exit")
+	flag.StringVar(&savePath, "save", savePath, "file the game is loaded from and saved to, empty to start a new game and save nothing")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "Usage: pokedex [flags] [run script.txt]\n       pokedex serve [flags]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
 
-	var cleanedSlice []string
-	for true {
-		fmt.Printf("Pokedex > ")
-		isComplete := scanner.Scan()
+	clientOpts := []pokeapi.Option{
+		pokeapi.WithBaseURL(*apiURL),
+		pokeapi.WithStaleWhileRevalidate(*staleWhileRevalidate),
+	}
 
-		if isComplete {
-			word = scanner.Text()
-			cleanedSlice = cleanInput(word)
+	switch {
+	case *replayPath != "":
+		tape, err := cassette.Load(*replayPath)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		clientOpts = append(clientOpts, pokeapi.WithTransport(cassette.NewReplayer(tape)))
+	case *recordPath != "":
+		clientOpts = append(clientOpts, pokeapi.WithTransport(cassette.NewRecorder(*recordPath, nil)))
+	}
 
-			if len(cleanedSlice) > 0 {
-				commandStr := cleanedSlice[0]
+	cache = newCache(*recordPath == "" && *replayPath == "")
+	client = pokeapi.NewClient(cache, clientOpts...)
 
-				command, ok := commands[commandStr]
+	interactive := false
+	ok := true
 
-				if !ok {
-					fmt.Println("Unknown command")
-				}
-				
-				if len(cleanedSlice) == 2 {
-					command.callback(cleanedSlice[1])
-				} else {
-					command.callback("")
-				}
-			}
+	// A save that cannot be read is left alone rather than replaced with
+	// this session's new game when it ends.
+	autosave := savePath != ""
+	if autosave {
+		if err := loadGame(savePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			reportError(err)
+			fmt.Fprintf(errOutput, "Not saving this session, to keep %s as it is\n", savePath)
+			autosave, ok = false, false
+		}
+	}
+
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "seed" {
+			c.reseed(*seed)
+		}
+	})
+
+	switch {
+	case *script != "":
+		ok = repl(strings.NewReader(strings.Join(splitCommands(*script), "\n")), false) && ok
+	case flag.Arg(0) == "run":
+		f, err := os.Open(flag.Arg(1))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		ok = repl(f, false) && ok
+		f.Close()
+	default:
+		interactive = isTerminal(os.Stdin)
+		ok = repl(os.Stdin, interactive) && ok
+	}
 
+	if autosave {
+		if err := saveGame(savePath); err != nil {
+			reportError(err)
+			ok = false
 		}
+	}
 
+	if !ok && !interactive {
+		os.Exit(1)
 	}
 }
 
-func commandExit(area_name string) error {
+func commandExit(args commandArgs) error {
 	fmt.Println("Closing the Pokedex... Goodbye!")
-	os.Exit(0)
-	return nil
+	return errExit
 }
 
-func commandHelp(area_name string) error {
-
-	fmt.Println("Welcome to the Pokedex!\nUsage: ")
+func commandCache(args commandArgs) error {
+	stats := cache.Stats()
 
-	for _, command := range commands {
-		fmt.Printf("%s: %s\n", command.name, command.description)
-	}
+	fmt.Printf("Entries: %v\n", cache.Len())
+	fmt.Printf("Hits: %v\n", stats.Hits)
+	fmt.Printf("Misses: %v\n", stats.Misses)
+	fmt.Printf("Evictions: %v\n", stats.Evictions)
 	return nil
 }
 
-func commandLocationAreaNext(area_name string) error {
-
-	config := commands["map"].config
-
-	basedUrl := "https://pokeapi.co/api/v2/location-area/"
-
-	if config.Next == nil {
-		config.Next = &basedUrl
-		config.Previous = nil
-	}
-
-	cacheRes, ok := cache.Get(*config.Next)
-
-	var locationArea locationArea
-	var err error
+func commandHelp(args commandArgs) error {
 
-	if ok {
-		locationArea, err = printHelper(cacheRes)
-		if err != nil {
-			return err
-		}
-	} else {
-		bytes, err := fetchHelper(*config.Next)
-		if err != nil {
-			return err
+	if name := args.arg(0); name != "" {
+		command, ok := commands[name]
+		if !ok {
+			return fmt.Errorf("no command named %s", name)
 		}
 
-		locationArea, err = printHelper(bytes)
-		if err != nil {
-			return err
+		fmt.Printf("%s: %s\nUsage: %s\n", command.name, command.description, command.usage())
+		for _, spec := range command.flags {
+			fmt.Printf("  --%s: %s\n", spec.name, spec.description)
 		}
+		return nil
 	}
 
-	config.Previous = locationArea.Previous
-	config.Next = locationArea.Next
+	fmt.Println("Welcome to the Pokedex!\nUsage: ")
 
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		command := commands[name]
+		fmt.Printf("%s: %s\n", command.usage(), command.description)
+	}
 	return nil
 }
 
-func fetchHelper(url string) ([]byte, error) {
-	client := &http.Client{}
+func commandLocationAreaNext(args commandArgs) error {
 
-	cacheRes, ok := cache.Get(url)
+	config := commands["map"].config
 
-	if ok {
-		return cacheRes, nil
+	if config.Next == nil {
+		firstPage := 0
+		config.Next = &firstPage
+		config.Previous = nil
 	}
 
+	return listLocationAreas(config, *config.Next)
+}
 
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("Accept", "application/json")
-
-	res, err := client.Do(req)
+// listLocationAreas prints the given page of location areas and points
+// config at the pages either side of it.
+func listLocationAreas(config *config, page int) error {
+	locationAreas, err := client.ListLocationAreas(page)
 	if err != nil {
-		return nil, err
+		return apiError(err)
 	}
-	defer res.Body.Close()
 
-	bytes, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
+	for _, res := range locationAreas.Results {
+		fmt.Println(res.Name)
+		seenLocationAreas[res.Name] = true
 	}
 
-	cache.Add(url, bytes)
-
-	return bytes, nil
-}
-
-func printHelper(val []byte) (locationArea, error) {
-
-	var locationArea locationArea = locationArea{}
-
-	err := json.Unmarshal(val, &locationArea)
-	if err != nil {
-		return locationArea, err
+	config.Previous = nil
+	if locationAreas.Previous != nil {
+		previous := page - 1
+		config.Previous = &previous
 	}
 
-	for _, res := range locationArea.Results {
-		fmt.Println(res.Name)
+	config.Next = nil
+	if locationAreas.Next != nil {
+		next := page + 1
+		config.Next = &next
 	}
 
-	return locationArea, nil
-
+	return nil
 }
 
-func commandLocationAreaPrevious(area_name string) error {
+func commandLocationAreaPrevious(args commandArgs) error {
 
 	config := commands["mapb"].config
-	
-	basedUrl := "https://pokeapi.co/api/v2/location-area/"
 
 	if config.Previous == nil {
 		fmt.Println("you're on the first page")
-		config.Next = &basedUrl
+		firstPage := 0
+		config.Next = &firstPage
 		return nil
 	}
 
-	cacheRes, ok := cache.Get(*config.Previous)
-
-	var locationArea locationArea
-	var err error
-
-	if ok {
-		locationArea, err = printHelper(cacheRes)
-		if err != nil {
-			return err
-		}
-	} else {
-		bytes, err := fetchHelper(*config.Previous)
-		if err != nil {
-			return err
-		}
+	return listLocationAreas(config, *config.Previous)
+}
 
-		locationArea, err = printHelper(bytes)
-		if err != nil {
-			return err
-		}
+// apiError rewords PokeAPI failures for the REPL.
+func apiError(err error) error {
+	switch {
+	case errors.Is(err, pokeapi.ErrRateLimited):
+		return errors.New("PokeAPI is rate limiting requests, try again in a minute")
+	case errors.Is(err, pokeapi.ErrServer):
+		return errors.New("PokeAPI is having trouble right now, try again later")
 	}
-
-	config.Previous = locationArea.Previous
-	config.Next = locationArea.Next
-
-	return nil
+	return err
 }
 
-func cleanInput(text string) []string {
-	res := strings.Fields(text)
-
-	values := []string{}
-
-	for _, s := range res {
-		values = append(values, strings.ToLower(s))
+// printPokemonHelper prints the name of each Pokemon in rows once.
+func printPokemonHelper(rows []encounterRow) {
+	printed := map[string]bool{}
+	for _, row := range rows {
+		if printed[row.pokemon] {
+			continue
+		}
+		printed[row.pokemon] = true
+		fmt.Println(row.pokemon)
+		seenPokemon[row.pokemon] = true
 	}
-
-	return values
 }
 
-func printPokemonHelper(val []byte) {
+func commandExplore(args commandArgs) error{
+	area_name := args.arg(0)
 
-	var locationArea LocationAreaPokemon = LocationAreaPokemon{}
-
-	err := json.Unmarshal(val, &locationArea)
+	filter, err := parseEncounterFilter(args)
 	if err != nil {
-		return
+		return err
 	}
 
-	for _, res := range locationArea.PokemonEncounters {
-		fmt.Println(res.Pokemon.Name)
+	locationArea, err := client.GetLocationArea(area_name)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return fmt.Errorf("no location area named %s", area_name)
 	}
-}
-
-func commandExplore(area_name string) error{
-	url := "https://pokeapi.co/api/v2/location-area/" + area_name
-	
-	res, err := fetchHelper(url)
 	if err != nil {
-		return err
+		return apiError(err)
 	}
 
-	printPokemonHelper(res) 
+	moveTo(locationArea)
+	rows := encounterRows(locationArea, filter)
+
+	if _, ok := args.flag("details"); ok {
+		printEncounterRates(os.Stdout, locationArea, filter)
+		printEncounterTable(os.Stdout, rows)
+		for _, row := range rows {
+			seenPokemon[row.pokemon] = true
+		}
+	} else {
+		printPokemonHelper(rows)
+	}
 
 	return  nil
 }
 
-var attemptedCatches map[string]int = map[string]int{}
-
-func commandCatch(pokemonName string) error {
+func commandCatch(args commandArgs) error {
 
-	url := "https://pokeapi.co/api/v2/pokemon/" + pokemonName
+	pokemonName := args.arg(0)
 
-	resBytes, err := fetchHelper(url)
+	throw, err := parseThrow(args)
 	if err != nil {
 		return err
 	}
 
-	var pokemon Pokemon
-
-	err = json.Unmarshal(resBytes, &pokemon)
+	pokemon, err := client.GetPokemon(pokemonName)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return fmt.Errorf("no Pokemon named %s", pokemonName)
+	}
+	if err != nil {
+		return apiError(err)
+	}
 
-	fmt.Printf("Throwing a Pokeball at %v...\n", pokemonName)
+	chance, err := encounterChance(pokemonName)
+	if err != nil {
+		return err
+	}
+	if inventory[throw.ball.item] == 0 {
+		return fmt.Errorf("you have no %ss left, buy some with buy %s", throw.ball.name, throw.ball.item)
+	}
+	rng := args.config.rand
+	if rng.Intn(100) >= chance {
+		fmt.Printf("No wild %v appeared, keep looking!\n", pokemonName)
+		return nil
+	}
 
-	if attemptedCatches[pokemonName] == 4 {
-		bag[pokemonName] = pokemon
-	} else if pokemon.BaseExperience < 100 && rand.Float64() < 0.90{
-			fmt.Printf("%v was caught!\nYou may now inspect it with the inspect command.\n", pokemonName)
-		bag[pokemonName] = pokemon
+	species, err := pokeapi.Fetch[model.PokemonSpecies](client, pokemon.Species.URL)
+	if err != nil {
+		return apiError(err)
+	}
 
-	} else if pokemon.BaseExperience < 200 && rand.Float64() < 0.75 {
-			fmt.Printf("%v was caught!\n", pokemonName)
-			bag[pokemonName] = pokemon
+	fmt.Printf("Throwing %v at %v...\n", withArticle(throw.ball.name), pokemonName)
+	useItem(throw.ball.item)
+
+	shakes, caught := throw.attempt(rng, species.CaptureRate)
+	fmt.Println(shakeMessage(pokemonName, shakes, caught))
+
+	if caught {
+		minLevel, maxLevel := encounterLevels(pokemonName)
+		instance := &caughtPokemon{
+			CaughtAt: time.Now(),
+			Location: currentArea.Name,
+			Level:    minLevel + rng.Intn(maxLevel-minLevel+1),
+			Species:  pokemon.Name,
+			IVs:      rollIVs(rng),
+			Nature:   rollNature(rng),
+		}
+		for _, member := range bag.Party {
+			member.gainEVs(pokemon)
+		}
+		where := bag.add(instance)
+		fmt.Printf("%v was caught and sent to %v as #%d!\nYou may now inspect it with the inspect command.\n", pokemonName, where, instance.ID)
 
-	} else if pokemon.BaseExperience < 300 && rand.Float64() < 0.5  {
-		fmt.Printf("%v was caught!\n", pokemonName)
-			bag[pokemonName] = pokemon
-	} else if pokemon.BaseExperience >= 300 && rand.Float64() < 0.25 {
-			fmt.Printf("%v was caught!\n", pokemonName)
-			bag[pokemonName] = pokemon
-	} else {
-		fmt.Printf("%v escaped!\n", pokemonName)
-		attemptedCatches[pokemonName] += 1
+		reward := pokemon.BaseExperience * catchRewardRate
+		money += reward
+		fmt.Printf("You earned %d Poke Dollars.\n", reward)
 	}
 
 	return nil
 }
 
-func commandPokedex(ignoreArg string) error {
+func commandPokedex(args commandArgs) error {
 	fmt.Printf("Your Pokedex:\n")
 
-	for key, _ := range bag {
+	for _, key := range bag.species() {
 		fmt.Printf(" - %v\n", key)
 	}
 	return nil
 }
 
-func commandInspect(pokemonName string) error {
+func commandInspect(args commandArgs) error {
+	level := 0
+	if value, ok := args.flag("level"); ok {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > 100 {
+			return fmt.Errorf("--level must be from 1 to 100, got %q", value)
+		}
+		level = n
+	}
+
+	var errs []error
+	for _, pokemonName := range args.rest(0) {
+		errs = append(errs, inspectPokemon(pokemonName, level))
+	}
+	return errors.Join(errs...)
+}
+
+// inspectPokemon prints a caught Pokemon, with its stats computed at
+// level, or at its own level if that is zero.
+func inspectPokemon(pokemonName string, level int) error {
 	
-	pokemon, ok := bag[pokemonName]
+	caught, err := bag.find(pokemonName)
 
-	if !ok {
-		return nil
+	if err != nil {
+		return err
+	}
+	pokemon, err := caught.pokemon()
+	if err != nil {
+		return err
+	}
+	if level == 0 {
+		level = caught.Level
 	}
 
 	fmt.Printf(`
+ID: %v
 Name: %v
+`, caught.ID, pokemon.Name);
+	if caught.Nickname != "" {
+		fmt.Printf("Nickname: %v\n", caught.Nickname)
+	}
+	if !caught.CaughtAt.IsZero() {
+		fmt.Printf("Caught: %v in %v\n", caught.CaughtAt.Format(time.DateTime), caught.Location)
+	}
+	fmt.Printf(`Level: %v
+Nature: %v
 Height: %v
 Weight: %v
-`, pokemonName, pokemon.Height, pokemon.Weight);
+`, caught.Level, natureNamed(caught.Nature).name, pokemon.Height, pokemon.Weight);
 
 
-	fmt.Println("Stats:")
-	interestedStats := map[string]int{
-		"hp" : 0,
-		"attack": 0,
-		"defense": 0,
-		"special-attack": 0,
-		"special-defense": 0,
-		"speed": 0,
-	}
+	fmt.Printf("Stats at Lv. %v:\n", level)
 
 	for _, stat := range pokemon.Stats {
-
-		_, ok := interestedStats[stat.Stat.Name]
-
-		if ok {
-			interestedStats[stat.Stat.Name] = stat.BaseStat
-		}
-	}
-
-	for key, value := range interestedStats {
-		fmt.Printf(" -%s: %v\n", key, value)
+		name := stat.Stat.Name
+		fmt.Printf(" -%s: %v (base %v, IV %v, EV %v)\n", name, caught.statAt(stat, level), stat.BaseStat, caught.IVs[name], caught.EVs[name])
 	}
 
 
@@ -752,6 +698,5 @@ Weight: %v
 	for _, t := range pokemon.Types {
 		fmt.Printf(" - %s\n", t.Type.Name)
 	}
-
 	return nil
 }
\ No newline at end of file
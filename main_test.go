@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestMain runs the pokedex itself instead of the tests when
+// POKEDEX_RUN_MAIN is set, so that runMain can start whole sessions.
+func TestMain(m *testing.M) {
+	if os.Getenv("POKEDEX_RUN_MAIN") != "" {
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// runMain runs the pokedex with args in a separate process, answering
+// PokeAPI requests from the test cassette, and returns what it printed
+// and whether it exited successfully.
+func runMain(t *testing.T, args ...string) (string, bool) {
+	args = append([]string{"-replay", "testdata/session.json"}, args...)
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Env = append(os.Environ(), "POKEDEX_RUN_MAIN=1")
+
+	out, err := cmd.CombinedOutput()
+	if _, exited := err.(*exec.ExitError); err != nil && !exited {
+		t.Fatalf("running pokedex: %v", err)
+	}
+	return string(out), err == nil
+}
+
+func TestUnreadableSaveSurvivesSession(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "pokedex", "save.json")
+
+	save := []byte(`{"version": 99, "bag": {}, "money": 99999}`)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, save, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, ok := runMain(t, "-c", "pokedex")
+	if ok {
+		t.Errorf("expected a save that cannot be read to fail the session:\n%s", out)
+	}
+	if data, err := os.ReadFile(path); err != nil || !bytes.Equal(data, save) {
+		t.Errorf("expected the save to be left as it was, got %s, %v", data, err)
+	}
+}
+
+func TestSeedReplaysScript(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+
+	script := "goto canalave-city-area" + strings.Repeat("; catch gyarados --hp=1 --status=sleep", 6) + "; party; items"
+	play := func() string {
+		out, ok := runMain(t, "-save", "", "-seed", "42", "-c", script)
+		if !ok {
+			t.Fatalf("expected the script to succeed:\n%s", out)
+		}
+		return out
+	}
+
+	out := play()
+	if !strings.Contains(out, "#1 gyarados") {
+		t.Fatalf("expected some catches to compare:\n%s", out)
+	}
+	if replayed := play(); replayed != out {
+		t.Errorf("expected the same seed to give the same session:\n%s\nthen:\n%s", out, replayed)
+	}
+	if entries, _ := os.ReadDir(home); len(entries) != 0 {
+		t.Errorf("expected a session without a save file to write nothing, have %v", entries)
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/chandanbsd/pokedex/internal/model"
+	"github.com/chandanbsd/pokedex/internal/pokeapi"
+)
+
+// typeNames lists the attacking types in PokeAPI's order.
+var typeNames = []string{
+	"normal", "fighting", "flying", "poison", "ground", "rock",
+	"bug", "ghost", "steel", "fire", "water", "grass",
+	"electric", "psychic", "ice", "dragon", "dark", "fairy",
+}
+
+// typeRelations returns the damage relations of the named type.
+func typeRelations(name string) (model.TypeRelations, error) {
+	t, err := client.GetType(name)
+	if err != nil {
+		return model.TypeRelations{}, err
+	}
+	return t.DamageRelations, nil
+}
+
+// effectiveness returns the damage multiplier of an attacking type against
+// a defender with the given types: 4, 2, 1, 0.5, 0.25 or 0.
+func effectiveness(attacking string, defending []string) (float64, error) {
+	multiplier := 1.0
+	for _, name := range defending {
+		relations, err := typeRelations(name)
+		if err != nil {
+			return 0, err
+		}
+		multiplier *= damageFrom(relations, attacking)
+	}
+	return multiplier, nil
+}
+
+// damageFrom returns the multiplier a type with the given relations takes
+// from an attacking type.
+func damageFrom(relations model.TypeRelations, attacking string) float64 {
+	has := func(types []model.NamedAPIResource[model.Type]) bool {
+		return slices.ContainsFunc(types, func(t model.NamedAPIResource[model.Type]) bool {
+			return t.Name == attacking
+		})
+	}
+
+	switch {
+	case has(relations.NoDamageFrom):
+		return 0
+	case has(relations.HalfDamageFrom):
+		return 0.5
+	case has(relations.DoubleDamageFrom):
+		return 2
+	}
+	return 1
+}
+
+func formatMultiplier(m float64) string {
+	return strconv.FormatFloat(m, 'f', -1, 64) + "x"
+}
+
+// combatant is one side of a matchup: a Pokemon, or a bare type.
+type combatant struct {
+	name  string
+	types []string
+}
+
+func (c combatant) String() string {
+	if len(c.types) == 1 && c.types[0] == c.name {
+		return c.name
+	}
+	return fmt.Sprintf("%s (%s)", c.name, strings.Join(c.types, "/"))
+}
+
+// lookupCombatant resolves name to a type if it names one, otherwise to
+// the types of the Pokemon with that name.
+func lookupCombatant(name string) (combatant, error) {
+	if slices.Contains(typeNames, name) {
+		return combatant{name: name, types: []string{name}}, nil
+	}
+
+	pokemon, err := client.GetPokemon(name)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return combatant{}, fmt.Errorf("no Pokemon or type named %s", name)
+	}
+	if err != nil {
+		return combatant{}, apiError(err)
+	}
+
+	result := combatant{name: name}
+	for _, t := range pokemon.Types {
+		result.types = append(result.types, t.Type.Name)
+	}
+	return result, nil
+}
+
+func pokemonOrTypeNames() []string {
+	return append(pokemonNames(), typeNames...)
+}
+
+func commandMatchup(args commandArgs) error {
+	attacker, err := lookupCombatant(args.arg(0))
+	if err != nil {
+		return err
+	}
+	defender, err := lookupCombatant(args.arg(1))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%v vs %v\n", attacker, defender)
+	for _, t := range attacker.types {
+		multiplier, err := effectiveness(t, defender.types)
+		if err != nil {
+			return apiError(err)
+		}
+		fmt.Printf("  %s: %s\n", t, formatMultiplier(multiplier))
+	}
+	return nil
+}
+
+func commandWeaknesses(args commandArgs) error {
+	defender, err := lookupCombatant(args.arg(0))
+	if err != nil {
+		return err
+	}
+
+	byMultiplier := map[float64][]string{}
+	for _, t := range typeNames {
+		multiplier, err := effectiveness(t, defender.types)
+		if err != nil {
+			return apiError(err)
+		}
+		byMultiplier[multiplier] = append(byMultiplier[multiplier], t)
+	}
+
+	fmt.Println(defender)
+	for _, multiplier := range []float64{4, 2, 1, 0.5, 0.25, 0} {
+		if types, ok := byMultiplier[multiplier]; ok {
+			fmt.Printf("  %s: %s\n", formatMultiplier(multiplier), strings.Join(types, ", "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/chandanbsd/pokedex/internal/model"
+)
+
+func TestDamageFrom(t *testing.T) {
+	ref := func(name string) model.NamedAPIResource[model.Type] {
+		return model.NamedAPIResource[model.Type]{Name: name}
+	}
+	flying := model.TypeRelations{
+		DoubleDamageFrom: []model.NamedAPIResource[model.Type]{ref("rock"), ref("electric"), ref("ice")},
+		HalfDamageFrom:   []model.NamedAPIResource[model.Type]{ref("fighting"), ref("bug"), ref("grass")},
+		NoDamageFrom:     []model.NamedAPIResource[model.Type]{ref("ground")},
+	}
+
+	cases := map[string]float64{"electric": 2, "bug": 0.5, "ground": 0, "water": 1}
+	for attacking, expected := range cases {
+		if actual := damageFrom(flying, attacking); actual != expected {
+			t.Errorf("%s against flying: expected %v, got %v", attacking, expected, actual)
+		}
+	}
+}
+
+func TestEffectiveness(t *testing.T) {
+	useCassette(t, "testdata/session.json")
+
+	cases := []struct {
+		attacking string
+		defending []string
+		expected  float64
+	}{
+		{"electric", []string{"water", "flying"}, 4},
+		{"water", []string{"rock", "ground"}, 4},
+		{"fighting", []string{"rock", "ground"}, 2},
+		{"bug", []string{"rock", "ground"}, 1},
+		{"normal", []string{"rock", "ground"}, 0.5},
+		{"poison", []string{"rock", "ground"}, 0.25},
+		{"ground", []string{"water", "flying"}, 0},
+	}
+
+	for _, c := range cases {
+		actual, err := effectiveness(c.attacking, c.defending)
+		if err != nil {
+			t.Fatalf("effectiveness: %v", err)
+		}
+		if actual != c.expected {
+			t.Errorf("%s against %v: expected %v, got %v", c.attacking, c.defending, c.expected, actual)
+		}
+	}
+
+	misses := cache.Stats().Misses
+	if _, err := effectiveness("electric", []string{"water", "flying"}); err != nil {
+		t.Fatalf("effectiveness: %v", err)
+	}
+	if cache.Stats().Misses != misses {
+		t.Errorf("expected the type chart to be served from the cache")
+	}
+}
+
+func TestMatchupCommands(t *testing.T) {
+	useCassette(t, "testdata/session.json")
+
+	if err := runCommand("matchup pikachu gyarados"); err != nil {
+		t.Errorf("matchup: %v", err)
+	}
+	if err := runCommand("weaknesses geodude"); err != nil {
+		t.Errorf("weaknesses: %v", err)
+	}
+	if err := runCommand("matchup fire pikachuu"); err == nil || err.Error() != "no Pokemon or type named pikachuu" {
+		t.Errorf("expected a friendly error for an unknown defender, got %v", err)
+	}
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/chandanbsd/pokedex/internal/model"
+	"github.com/chandanbsd/pokedex/internal/pokeapi"
+)
+
+// learnMethods are the move learn methods the moves command filters on,
+// in the order it lists them.
+var learnMethods = []string{"level-up", "machine", "egg", "tutor"}
+
+// learnedMove is one way a Pokemon learns a move in one version group.
+type learnedMove struct {
+	name         string
+	method       string
+	level        int
+	versionGroup string
+}
+
+func commandMoves(args commandArgs) error {
+	pokemonName := args.arg(0)
+
+	caught, err := bag.find(pokemonName)
+	if err != nil {
+		return err
+	}
+	pokemon, err := caught.pokemon()
+	if err != nil {
+		return err
+	}
+
+	method, _ := args.flag("method")
+	if method != "" && !slices.Contains(learnMethods, method) {
+		return fmt.Errorf("unknown learn method %q, expected one of %s", method, strings.Join(learnMethods, ", "))
+	}
+	versionGroup := ""
+	if version, _ := args.flag("version"); version != "" {
+		versionGroup, err = versionGroupOf(version)
+		if err != nil {
+			return err
+		}
+	}
+
+	moves := learnedMoves(pokemon, versionGroup, method)
+	if len(moves) == 0 {
+		fmt.Printf("%s learns no moves matching those filters\n", pokemonName)
+		return nil
+	}
+
+	var details map[string]model.Move
+	if _, ok := args.flag("details"); ok {
+		details = map[string]model.Move{}
+		for _, move := range moves {
+			if _, ok := details[move.name]; ok {
+				continue
+			}
+			m, err := client.GetMove(move.name)
+			if err != nil {
+				return apiError(err)
+			}
+			details[move.name] = m
+		}
+	}
+
+	printMoves(os.Stdout, moves, details)
+	return nil
+}
+
+// versionGroupOf returns the version group a game is in, so "red" gives
+// "red-blue". A name that is not a game is taken to be a version group's.
+func versionGroupOf(version string) (string, error) {
+	v, err := client.GetVersion(version)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return version, nil
+	}
+	if err != nil {
+		return "", apiError(err)
+	}
+	return v.VersionGroup.Name, nil
+}
+
+// learnedMoves flattens a Pokemon's moves into one entry per learn method
+// and version group, keeping those matching versionGroup and method when
+// set. Level-up moves come first, by level.
+func learnedMoves(pokemon model.Pokemon, versionGroup, method string) []learnedMove {
+	var moves []learnedMove
+	for _, move := range pokemon.Moves {
+		for _, detail := range move.VersionGroupDetails {
+			if method != "" && detail.MoveLearnMethod.Name != method {
+				continue
+			}
+			if versionGroup != "" && detail.VersionGroup.Name != versionGroup {
+				continue
+			}
+			moves = append(moves, learnedMove{
+				name:         move.Move.Name,
+				method:       detail.MoveLearnMethod.Name,
+				level:        detail.LevelLearnedAt,
+				versionGroup: detail.VersionGroup.Name,
+			})
+		}
+	}
+
+	methodOrder := func(method string) int {
+		if i := slices.Index(learnMethods, method); i >= 0 {
+			return i
+		}
+		return len(learnMethods)
+	}
+	slices.SortStableFunc(moves, func(a, b learnedMove) int {
+		if c := methodOrder(a.method) - methodOrder(b.method); c != 0 {
+			return c
+		}
+		if c := a.level - b.level; c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.name, b.name); c != 0 {
+			return c
+		}
+		return strings.Compare(a.versionGroup, b.versionGroup)
+	})
+	return moves
+}
+
+// printMoves prints moves as a table, with the type, power, accuracy and
+// PP of each move if details is set.
+func printMoves(w io.Writer, moves []learnedMove, details map[string]model.Move) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	header := "LEVEL\tMOVE\tMETHOD\tVERSION"
+	if details != nil {
+		header += "\tTYPE\tPOWER\tACCURACY\tPP"
+	}
+	fmt.Fprintln(tw, header)
+
+	for _, move := range moves {
+		level := "-"
+		if move.method == "level-up" {
+			level = strconv.Itoa(move.level)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s", level, move.name, move.method, move.versionGroup)
+
+		if details != nil {
+			m := details[move.name]
+			fmt.Fprintf(tw, "\t%s\t%s\t%s\t%s", m.Type.Name, optionalInt(m.Power), optionalInt(m.Accuracy), optionalInt(m.PP))
+		}
+		fmt.Fprintln(tw)
+	}
+
+	tw.Flush()
+}
+
+func optionalInt(n *int) string {
+	if n == nil {
+		return "-"
+	}
+	return strconv.Itoa(*n)
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/chandanbsd/pokedex/internal/model"
+)
+
+func TestLearnedMoves(t *testing.T) {
+	pikachu := loadFixture[model.Pokemon](t, "fixtures/pokeapi/pokemon/pikachu.json")
+
+	moves := learnedMoves(pikachu, "red-blue", "")
+	var names []string
+	for _, move := range moves {
+		if move.versionGroup != "red-blue" {
+			t.Errorf("expected only red-blue moves, got %+v", move)
+		}
+		names = append(names, move.name)
+	}
+	expected := "growl thunder-shock tail-whip thunder-wave quick-attack swift thunder thunder thunderbolt"
+	if actual := strings.Join(names, " "); actual != expected {
+		t.Errorf("expected %s, got %s", expected, actual)
+	}
+
+	for _, move := range learnedMoves(pikachu, "", "egg") {
+		if move.method != "egg" {
+			t.Errorf("expected only egg moves, got %+v", move)
+		}
+	}
+	if moves := learnedMoves(pikachu, "emerald", ""); len(moves) != 0 {
+		t.Errorf("expected no moves for an unknown version, got %+v", moves)
+	}
+}
+
+func TestVersionGroupOf(t *testing.T) {
+	useCassette(t, "testdata/session.json")
+
+	// A Pokemon learning one move in each of these version groups.
+	var pokemon model.Pokemon
+	for _, group := range []string{"red-blue", "diamond-pearl", "brilliant-diamond-and-shining-pearl",
+		"black-white", "black-2-white-2", "sun-moon", "ultra-sun-ultra-moon"} {
+		pokemon.Moves = append(pokemon.Moves, model.PokemonMove{
+			Move: model.NamedAPIResource[model.Move]{Name: "tackle"},
+			VersionGroupDetails: []model.PokemonMoveVersion{{
+				VersionGroup:    model.NamedAPIResource[model.VersionGroup]{Name: group},
+				MoveLearnMethod: model.NamedAPIResource[model.MoveLearnMethod]{Name: "level-up"},
+			}},
+		})
+	}
+
+	// Games are looked up rather than matched against the words of group
+	// names, so "sun" is not in ultra-sun-ultra-moon.
+	cases := []struct {
+		version  string
+		expected []string
+	}{
+		{version: "red", expected: []string{"red-blue"}},
+		{version: "2", expected: []string{"red-blue"}},
+		{version: "red-blue", expected: []string{"red-blue"}},
+		{version: "diamond", expected: []string{"diamond-pearl"}},
+		{version: "black", expected: []string{"black-white"}},
+		{version: "sun", expected: []string{"sun-moon"}},
+		{version: "and"},
+	}
+	for _, c := range cases {
+		group, err := versionGroupOf(c.version)
+		if err != nil {
+			t.Errorf("versionGroupOf(%q): %v", c.version, err)
+			continue
+		}
+		var groups []string
+		for _, move := range learnedMoves(pokemon, group, "") {
+			groups = append(groups, move.versionGroup)
+		}
+		if !slices.Equal(groups, c.expected) {
+			t.Errorf("%s: expected moves from %v, got %v", c.version, c.expected, groups)
+		}
+	}
+}
+
+func TestMovesDetails(t *testing.T) {
+	useCassette(t, "testdata/session.json")
+	bag = storageOf("pikachu")
+	t.Cleanup(func() { bag = newPokemonStorage() })
+
+	if err := runCommand("moves pikachu --version=red --details"); err != nil {
+		t.Errorf("moves: %v", err)
+	}
+	if err := runCommand("moves pikachu --method=hm"); err == nil {
+		t.Errorf("expected an unknown learn method to fail")
+	}
+	if err := runCommand("moves eevee"); err == nil || err.Error() != "you have not caught eevee" {
+		t.Errorf("expected an error for an uncaught Pokemon, got %v", err)
+	}
+}
@@ -0,0 +1,324 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/chandanbsd/pokedex/internal/model"
+)
+
+const (
+	partySize = 6
+	boxSize   = 30
+)
+
+// caughtPokemon is one Pokemon the player has caught. Catching a species
+// twice gives two of them, told apart by ID.
+type caughtPokemon struct {
+	ID       int       `json:"id"`
+	Nickname string    `json:"nickname,omitempty"`
+	CaughtAt time.Time `json:"caught_at"`
+	// Location is the location area it was caught in.
+	Location string `json:"location,omitempty"`
+	Level    int    `json:"level"`
+	// Species names the pokemon resource it is one of, which is fetched
+	// through the client's cache when its details are needed rather than
+	// kept in the save file.
+	Species string `json:"species"`
+	// IVs and EVs are keyed by stat name. IVs and the nature are fixed
+	// when the Pokemon is caught; EVs grow with every Pokemon the party
+	// catches.
+	IVs    map[string]int `json:"ivs,omitempty"`
+	EVs    map[string]int `json:"evs,omitempty"`
+	Nature string         `json:"nature,omitempty"`
+}
+
+// name is the Pokemon's nickname, or its species if it has none.
+func (p *caughtPokemon) name() string {
+	if p.Nickname != "" {
+		return p.Nickname
+	}
+	return p.Species
+}
+
+// label names the Pokemon along with its ID, species and level, as in
+// "#3 sparky (pikachu) Lv. 12".
+func (p *caughtPokemon) label() string {
+	name := p.Species
+	if p.Nickname != "" {
+		name = fmt.Sprintf("%s (%s)", p.Nickname, p.Species)
+	}
+	return fmt.Sprintf("#%d %s Lv. %d", p.ID, name, p.Level)
+}
+
+// pokemon fetches the pokemon resource of p's species.
+func (p *caughtPokemon) pokemon() (model.Pokemon, error) {
+	pokemon, err := client.GetPokemon(p.Species)
+	if err != nil {
+		return model.Pokemon{}, apiError(err)
+	}
+	return pokemon, nil
+}
+
+// pokemonStorage holds every caught Pokemon: up to partySize travel with
+// the player, and the rest are kept in PC boxes of boxSize each.
+type pokemonStorage struct {
+	Party  []*caughtPokemon   `json:"party"`
+	Boxes  [][]*caughtPokemon `json:"boxes"`
+	NextID int                `json:"next_id"`
+}
+
+var bag = newPokemonStorage()
+
+func newPokemonStorage() *pokemonStorage {
+	return &pokemonStorage{NextID: 1}
+}
+
+// add stores a newly caught Pokemon, in the party if there is room and
+// otherwise in the first box that has some, and says where it went.
+func (s *pokemonStorage) add(p *caughtPokemon) string {
+	p.ID = s.NextID
+	s.NextID++
+
+	if len(s.Party) < partySize {
+		s.Party = append(s.Party, p)
+		return "your party"
+	}
+	return s.store(p)
+}
+
+// store puts p in the first box with room, adding a box if they are all
+// full.
+func (s *pokemonStorage) store(p *caughtPokemon) string {
+	for i, box := range s.Boxes {
+		if len(box) < boxSize {
+			s.Boxes[i] = append(box, p)
+			return fmt.Sprintf("box %d", i+1)
+		}
+	}
+	s.Boxes = append(s.Boxes, []*caughtPokemon{p})
+	return fmt.Sprintf("box %d", len(s.Boxes))
+}
+
+// all returns every caught Pokemon, the party first.
+func (s *pokemonStorage) all() []*caughtPokemon {
+	all := slices.Clone(s.Party)
+	for _, box := range s.Boxes {
+		all = append(all, box...)
+	}
+	return all
+}
+
+func (s *pokemonStorage) len() int {
+	return len(s.all())
+}
+
+// find looks up a caught Pokemon by ID, nickname or species. A name more
+// than one Pokemon answers to, such as a species the player has two of,
+// must be picked by ID or nickname.
+func (s *pokemonStorage) find(ref string) (*caughtPokemon, error) {
+	if id, err := strconv.Atoi(strings.TrimPrefix(ref, "#")); err == nil {
+		for _, p := range s.all() {
+			if p.ID == id {
+				return p, nil
+			}
+		}
+		return nil, fmt.Errorf("you have no Pokemon with ID %d", id)
+	}
+
+	var matches []*caughtPokemon
+	for _, p := range s.all() {
+		if strings.EqualFold(p.Nickname, ref) || p.Species == ref {
+			matches = append(matches, p)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("you have not caught %s", ref)
+	case 1:
+		return matches[0], nil
+	}
+	ids := make([]string, len(matches))
+	for i, p := range matches {
+		ids[i] = strconv.Itoa(p.ID)
+	}
+	return nil, fmt.Errorf("you have %d %s, pick one by ID: %s", len(matches), ref, strings.Join(ids, ", "))
+}
+
+// remove takes p out of wherever it is kept. Empty boxes at the end are
+// dropped, but not ones before a box still in use, so boxes keep their
+// numbers.
+func (s *pokemonStorage) remove(p *caughtPokemon) {
+	isP := func(q *caughtPokemon) bool { return q == p }
+
+	s.Party = slices.DeleteFunc(s.Party, isP)
+	for i := range s.Boxes {
+		s.Boxes[i] = slices.DeleteFunc(s.Boxes[i], isP)
+	}
+	for len(s.Boxes) > 0 && len(s.Boxes[len(s.Boxes)-1]) == 0 {
+		s.Boxes = s.Boxes[:len(s.Boxes)-1]
+	}
+}
+
+// checkNickname reports whether p can be called nickname. Nicknames and
+// species both pick Pokemon, so a nickname must not be another Pokemon's
+// or the name of a species the player has caught.
+func (s *pokemonStorage) checkNickname(p *caughtPokemon, nickname string) error {
+	for _, other := range s.all() {
+		if strings.EqualFold(other.Species, nickname) {
+			return fmt.Errorf("%s is a species you have caught, pick another nickname", nickname)
+		}
+		if other != p && strings.EqualFold(other.Nickname, nickname) {
+			return fmt.Errorf("#%d is already called %s", other.ID, other.Nickname)
+		}
+	}
+	return nil
+}
+
+func (s *pokemonStorage) inParty(p *caughtPokemon) bool {
+	return slices.Contains(s.Party, p)
+}
+
+// species returns the species the player has caught, each once.
+func (s *pokemonStorage) species() []string {
+	names := map[string]bool{}
+	for _, p := range s.all() {
+		names[p.Species] = true
+	}
+	return sortedKeys(names)
+}
+
+// refs returns the names caught Pokemon can be referred to by, for tab
+// completion.
+func (s *pokemonStorage) refs() []string {
+	names := map[string]bool{}
+	for _, p := range s.all() {
+		names[p.Species] = true
+		if p.Nickname != "" {
+			names[strings.ToLower(p.Nickname)] = true
+		}
+	}
+	return sortedKeys(names)
+}
+
+func printCaught(w io.Writer, pokemon []*caughtPokemon) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for i, p := range pokemon {
+		fmt.Fprintf(tw, "%d.\t%s\t%s\n", i+1, p.label(), p.Location)
+	}
+	tw.Flush()
+}
+
+func commandParty(args commandArgs) error {
+	fmt.Printf("Party (%d/%d):\n", len(bag.Party), partySize)
+	printCaught(os.Stdout, bag.Party)
+	return nil
+}
+
+func commandBox(args commandArgs) error {
+	if len(bag.Boxes) == 0 {
+		fmt.Println("Your PC boxes are empty")
+		return nil
+	}
+
+	boxes := make([]int, len(bag.Boxes))
+	for i := range boxes {
+		boxes[i] = i
+	}
+	if value := args.arg(0); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > len(bag.Boxes) {
+			return fmt.Errorf("no box %s, you have boxes 1 to %d", value, len(bag.Boxes))
+		}
+		boxes = []int{n - 1}
+	}
+
+	for _, i := range boxes {
+		fmt.Printf("Box %d (%d/%d):\n", i+1, len(bag.Boxes[i]), boxSize)
+		printCaught(os.Stdout, bag.Boxes[i])
+	}
+	return nil
+}
+
+func commandDeposit(args commandArgs) error {
+	p, err := bag.find(args.arg(0))
+	if err != nil {
+		return err
+	}
+	if !bag.inParty(p) {
+		return fmt.Errorf("%s is not in your party", p.name())
+	}
+	if len(bag.Party) == 1 {
+		return errors.New("you must keep at least one Pokemon in your party")
+	}
+
+	bag.remove(p)
+	fmt.Printf("%s was sent to %s\n", p.name(), bag.store(p))
+	return nil
+}
+
+func commandWithdraw(args commandArgs) error {
+	p, err := bag.find(args.arg(0))
+	if err != nil {
+		return err
+	}
+	if bag.inParty(p) {
+		return fmt.Errorf("%s is already in your party", p.name())
+	}
+	if len(bag.Party) >= partySize {
+		return errors.New("your party is full, deposit a Pokemon first")
+	}
+
+	bag.remove(p)
+	bag.Party = append(bag.Party, p)
+	fmt.Printf("%s joined your party\n", p.name())
+	return nil
+}
+
+func commandNickname(args commandArgs) error {
+	p, err := bag.find(args.arg(0))
+	if err != nil {
+		return err
+	}
+
+	nickname := args.arg(1)
+	if _, err := strconv.Atoi(strings.TrimPrefix(nickname, "#")); err == nil {
+		return errors.New("a nickname cannot be a number, those pick Pokemon by ID")
+	}
+	if nickname != "" {
+		if err := bag.checkNickname(p, nickname); err != nil {
+			return err
+		}
+	}
+
+	old := p.name()
+	p.Nickname = nickname
+	if nickname == "" {
+		fmt.Printf("%s no longer has a nickname\n", old)
+	} else {
+		fmt.Printf("%s is now called %s\n", old, nickname)
+	}
+	return nil
+}
+
+func commandRelease(args commandArgs) error {
+	p, err := bag.find(args.arg(0))
+	if err != nil {
+		return err
+	}
+	if bag.inParty(p) && len(bag.Party) == 1 && bag.len() > 1 {
+		return errors.New("you must keep at least one Pokemon in your party, withdraw another first")
+	}
+
+	bag.remove(p)
+	fmt.Printf("%s was released. Bye, %s!\n", p.label(), p.name())
+	return nil
+}
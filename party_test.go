@@ -0,0 +1,142 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+// storageOf returns storage holding one caught Pokemon of each species
+// given, in order.
+func storageOf(species ...string) *pokemonStorage {
+	storage := newPokemonStorage()
+	for _, name := range species {
+		storage.add(&caughtPokemon{Level: 5, Species: name})
+	}
+	return storage
+}
+
+func TestStorageAdd(t *testing.T) {
+	storage := newPokemonStorage()
+	for i := 0; i < partySize+boxSize+1; i++ {
+		storage.add(&caughtPokemon{Species: "pidgey"})
+	}
+
+	if len(storage.Party) != partySize || len(storage.Boxes) != 2 ||
+		len(storage.Boxes[0]) != boxSize || len(storage.Boxes[1]) != 1 {
+		t.Errorf("expected a full party, a full box and one more, got %d, %d boxes",
+			len(storage.Party), len(storage.Boxes))
+	}
+	if last := storage.Boxes[1][0]; last.ID != partySize+boxSize+1 {
+		t.Errorf("expected IDs to count up, the last is %d", last.ID)
+	}
+}
+
+func TestStorageRemoveKeepsBoxNumbers(t *testing.T) {
+	storage := newPokemonStorage()
+	for i := 0; i < partySize+2*boxSize+1; i++ {
+		storage.add(&caughtPokemon{Species: "pidgey"})
+	}
+	last := storage.Boxes[2][0]
+
+	for _, p := range slices.Clone(storage.Boxes[1]) {
+		storage.remove(p)
+	}
+	if len(storage.Boxes) != 3 || storage.Boxes[2][0] != last {
+		t.Errorf("expected emptying box 2 to leave box 3 where it was")
+	}
+
+	storage.remove(last)
+	if len(storage.Boxes) != 1 {
+		t.Errorf("expected the empty boxes at the end to be dropped, have %d boxes", len(storage.Boxes))
+	}
+}
+
+func TestStorageFind(t *testing.T) {
+	storage := storageOf("pidgey", "pikachu", "pidgey", "raichu")
+	storage.Party[2].Nickname = "Gale"
+	// A nickname that became a caught species, as by evolving, is
+	// ambiguous rather than picking the nicknamed Pokemon.
+	storage.Party[1].Nickname = "Raichu"
+
+	cases := []struct {
+		ref      string
+		expected int
+		err      string
+	}{
+		{ref: "pikachu", expected: 2},
+		{ref: "3", expected: 3},
+		{ref: "#1", expected: 1},
+		{ref: "gale", expected: 3},
+		{ref: "pidgey", err: "you have 2 pidgey, pick one by ID: 1, 3"},
+		{ref: "raichu", err: "you have 2 raichu, pick one by ID: 2, 4"},
+		{ref: "7", err: "you have no Pokemon with ID 7"},
+		{ref: "mewtwo", err: "you have not caught mewtwo"},
+	}
+
+	for _, c := range cases {
+		p, err := storage.find(c.ref)
+		if c.err != "" {
+			if err == nil || err.Error() != c.err {
+				t.Errorf("find(%q): expected %q, got %v", c.ref, c.err, err)
+			}
+			continue
+		}
+		if err != nil || p.ID != c.expected {
+			t.Errorf("find(%q): expected #%d, got %v, %v", c.ref, c.expected, p, err)
+		}
+	}
+}
+
+func TestPartyCommands(t *testing.T) {
+	bag = storageOf("pikachu", "pidgey")
+	t.Cleanup(func() { bag = newPokemonStorage() })
+
+	for _, command := range []string{"nickname pikachu Sparky", "deposit sparky"} {
+		if err := runCommand(command); err != nil {
+			t.Fatalf("%s: %v", command, err)
+		}
+	}
+	if len(bag.Party) != 1 || len(bag.Boxes) != 1 || bag.Boxes[0][0].Nickname != "Sparky" {
+		t.Fatalf("expected Sparky to be in a box")
+	}
+
+	if err := runCommand("deposit pidgey"); err == nil {
+		t.Errorf("expected the last party member to stay")
+	}
+	if err := runCommand("release pidgey"); err == nil {
+		t.Errorf("expected the last party member not to be released while others are boxed")
+	}
+	if err := runCommand("nickname pidgey 12"); err == nil {
+		t.Errorf("expected a numeric nickname to fail")
+	}
+	if err := runCommand("nickname pidgey sparky"); err == nil || err.Error() != "#1 is already called Sparky" {
+		t.Errorf("expected a taken nickname to fail, got %v", err)
+	}
+	if err := runCommand("nickname pidgey Pikachu"); err == nil || err.Error() != "Pikachu is a species you have caught, pick another nickname" {
+		t.Errorf("expected a caught species as a nickname to fail, got %v", err)
+	}
+
+	if err := runCommand("withdraw 1"); err != nil {
+		t.Fatalf("withdraw: %v", err)
+	}
+	if err := runCommand("release pidgey"); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	if len(bag.Party) != 1 || bag.Party[0].name() != "Sparky" || len(bag.Boxes) != 0 {
+		t.Errorf("expected only Sparky to be left, in the party")
+	}
+}
+
+func TestInspectReportsMissingPokemon(t *testing.T) {
+	useCassette(t, "testdata/session.json")
+	bag = storageOf("pikachu")
+	t.Cleanup(func() { bag = newPokemonStorage() })
+
+	err := runCommand("inspect pikachu mewtwo")
+	if err == nil || err.Error() != "you have not caught mewtwo" {
+		t.Errorf("expected inspect to fail for mewtwo, got %v", err)
+	}
+	if err := runCommand("inspect pikachu --level=50"); err != nil {
+		t.Errorf("inspect: %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"strings"
+)
+
+// errExit is returned by the exit command to end the session.
+var errExit = errors.New("exit")
+
+// repl runs commands read from r, one per line, until EOF or the exit
+// command. When interactive is set it prompts for each line, and a
+// terminal on r gets line editing, history and tab completion. It reports
+// whether every command succeeded.
+func repl(r io.Reader, interactive bool) bool {
+	var lines lineReader = scannerReader{scanner: bufio.NewScanner(r)}
+	if interactive {
+		lines = scannerReader{scanner: bufio.NewScanner(r), prompt: prompt}
+		if f, isFile := r.(*os.File); isFile && isTerminal(f) {
+			lines = newTerminalReader(f, os.Stdout)
+		}
+	}
+
+	ok := true
+
+	for {
+		line, err := lines.ReadLine()
+		if err == io.EOF {
+			return ok
+		}
+		if err != nil {
+			reportError(err)
+			return false
+		}
+
+		err = runCommand(line)
+		if errors.Is(err, errExit) {
+			return ok
+		}
+		if err != nil {
+			reportError(err)
+			ok = false
+		}
+	}
+}
+
+// runCommand runs a single line of input. Blank lines and lines starting
+// with # are ignored.
+func runCommand(line string) error {
+	if strings.HasPrefix(strings.TrimSpace(line), "#") {
+		return nil
+	}
+
+	words, err := splitArgs(line)
+	if err != nil {
+		return err
+	}
+	if len(words) == 0 {
+		return nil
+	}
+
+	name := strings.ToLower(words[0])
+	command, ok := commands[name]
+	if !ok {
+		return unknownCommandError(name)
+	}
+
+	args, err := parseArgs(command, words[1:])
+	if err != nil {
+		return err
+	}
+
+	return dispatch(command, args)
+}
+
+func cleanInput(text string) []string {
+	res := strings.Fields(text)
+
+	values := []string{}
+
+	for _, s := range res {
+		values = append(values, strings.ToLower(s))
+	}
+
+	return values
+}
+
+// isTerminal reports whether f is attached to a terminal rather than a
+// pipe or file.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -48,3 +49,34 @@ func TestCleanInput(t *testing.T) {
 		}
 	}
 }
+
+func TestREPL(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected bool
+	}{
+		{
+			input:    "help\n\n# a comment\npokedex\n",
+			expected: true,
+		},
+		{
+			input:    "help\nbogus\npokedex\n",
+			expected: false,
+		},
+		{
+			input:    "pokedex\nexit\nbogus\n",
+			expected: true,
+		},
+		{
+			input:    "inspect nosuch\n",
+			expected: false,
+		},
+	}
+
+	for _, c := range cases {
+		actual := repl(strings.NewReader(c.input), false)
+		if actual != c.expected {
+			t.Errorf("repl(%q) = %v, expected %v", c.input, actual, c.expected)
+		}
+	}
+}
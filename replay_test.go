@@ -0,0 +1,62 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/chandanbsd/pokedex/internal/cassette"
+	"github.com/chandanbsd/pokedex/internal/pokeapi"
+)
+
+// useCassette points the REPL's client at the responses recorded in path.
+func useCassette(t *testing.T, path string) {
+	tape, err := cassette.Load(path)
+	if err != nil {
+		t.Fatalf("loading cassette: %v", err)
+	}
+
+	cache = newCache(false)
+	t.Cleanup(cache.Close)
+	client = pokeapi.NewClient(cache, pokeapi.WithTransport(cassette.NewReplayer(tape)))
+}
+
+func TestMapPaging(t *testing.T) {
+	useCassette(t, "testdata/session.json")
+	c.Next, c.Previous = nil, nil
+
+	if err := commandLocationAreaNext(commandArgs{}); err != nil {
+		t.Fatalf("map: %v", err)
+	}
+	if c.Next == nil || *c.Next != 1 || c.Previous != nil {
+		t.Fatalf("expected to be on the first page")
+	}
+
+	if err := commandLocationAreaNext(commandArgs{}); err != nil {
+		t.Fatalf("map: %v", err)
+	}
+	if c.Next != nil || c.Previous == nil || *c.Previous != 0 {
+		t.Fatalf("expected to be on the last page")
+	}
+
+	if err := commandLocationAreaPrevious(commandArgs{}); err != nil {
+		t.Fatalf("mapb: %v", err)
+	}
+	if c.Next == nil || *c.Next != 1 || c.Previous != nil {
+		t.Fatalf("expected to be back on the first page")
+	}
+}
+
+func TestExploreAndCatch(t *testing.T) {
+	useCassette(t, "testdata/session.json")
+
+	if err := runCommand("explore canalave-city-area"); err != nil {
+		t.Errorf("explore: %v", err)
+	}
+
+	if err := runCommand("catch pikachuu"); err == nil || err.Error() != "no Pokemon named pikachuu" {
+		t.Errorf("expected a friendly error for a missing Pokemon, got %v", err)
+	}
+
+	if err := runCommand("explore not-recorded-area"); err == nil {
+		t.Errorf("expected unrecorded requests to fail")
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"time"
+)
+
+func init() {
+	c.reseed(time.Now().UnixNano())
+}
+
+// reseed restarts the random source commands draw from, so that the same
+// seed and the same commands give the same catches.
+func (c *config) reseed(seed int64) {
+	c.seed = seed
+	c.rand = rand.New(rand.NewSource(seed))
+}
+
+func commandSeed(args commandArgs) error {
+	value := args.arg(0)
+	if value == "" {
+		fmt.Printf("Seed: %d\n", args.config.seed)
+		return nil
+	}
+
+	seed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return fmt.Errorf("seed must be a whole number, got %q", value)
+	}
+	args.config.reseed(seed)
+	fmt.Printf("Seed set to %d\n", seed)
+	return nil
+}
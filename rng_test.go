@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"testing"
+)
+
+func TestSeedReplaysCatches(t *testing.T) {
+	useCassette(t, "testdata/session.json")
+	t.Cleanup(func() { currentArea, inventory = nil, startingInventory() })
+
+	play := func() (map[int]string, int) {
+		bag = newPokemonStorage()
+		inventory = map[string]int{"great-ball": 10}
+		if err := runCommand("seed 42"); err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+		if err := runCommand("goto canalave-city-area"); err != nil {
+			t.Fatalf("goto: %v", err)
+		}
+		for i := 0; i < 10; i++ {
+			if err := runCommand("catch gyarados --ball=great --hp=1 --status=sleep"); err != nil {
+				t.Fatalf("catch: %v", err)
+			}
+		}
+
+		// The level, nature and IVs of the catches, by ID.
+		caught := map[int]string{}
+		for _, p := range bag.all() {
+			caught[p.ID] = fmt.Sprint(p.Level, p.Nature, p.IVs)
+		}
+		return caught, inventory["great-ball"]
+	}
+
+	caught, ballsLeft := play()
+	if len(caught) == 0 {
+		t.Fatalf("expected some catches to compare")
+	}
+	replayedCaught, replayedBallsLeft := play()
+	if !maps.Equal(caught, replayedCaught) || ballsLeft != replayedBallsLeft {
+		t.Errorf("expected the same seed to give the same catches: %v with %d balls left, then %v with %d",
+			caught, ballsLeft, replayedCaught, replayedBallsLeft)
+	}
+
+	if c.seed != 42 {
+		t.Errorf("expected the seed command to set the seed, got %d", c.seed)
+	}
+	if err := runCommand("seed forty-two"); err == nil {
+		t.Errorf("expected a non-numeric seed to fail")
+	}
+}
@@ -0,0 +1,290 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/chandanbsd/pokedex/internal/model"
+)
+
+// saveFileVersion is bumped whenever the layout of saveFile changes, with
+// a migration from the previous version added to saveMigrations.
+const saveFileVersion = 6
+
+type saveFile struct {
+	Version int             `json:"version"`
+	Bag     *pokemonStorage `json:"bag"`
+	// Seed is what the random source is seeded with when the save is
+	// loaded, so replaying the same commands from it gives the same
+	// catches.
+	Seed      *int64         `json:"seed,omitempty"`
+	Inventory map[string]int `json:"inventory"`
+	Money     int            `json:"money"`
+}
+
+// saveMigrations upgrade a decoded save file from the version they are
+// keyed by to the next one.
+var saveMigrations = map[int]func(save map[string]json.RawMessage) error{
+	// Version 2 added the seed. Older saves have none, and keep the
+	// session's own.
+	1: func(save map[string]json.RawMessage) error {
+		return nil
+	},
+	// Version 3 added items and money. Older saves start with what a new
+	// player gets.
+	2: func(save map[string]json.RawMessage) error {
+		var err error
+		if save["inventory"], err = json.Marshal(startingInventory()); err != nil {
+			return err
+		}
+		save["money"], err = json.Marshal(startingMoney)
+		return err
+	},
+	// Version 4 replaced the map of one Pokemon per species with the
+	// party and PC boxes. Older saves did not record where or at what
+	// level a Pokemon was caught. Only the species is kept of the
+	// pokemon resource the old map held, as version 6 does.
+	3: func(save map[string]json.RawMessage) error {
+		var old map[string]model.Pokemon
+		if err := json.Unmarshal(save["bag"], &old); err != nil {
+			return err
+		}
+
+		storage := newPokemonStorage()
+		for _, name := range sortedKeys(old) {
+			storage.add(&caughtPokemon{Level: legacyLevel, Species: old[name].Name})
+		}
+
+		var err error
+		save["bag"], err = json.Marshal(storage)
+		return err
+	},
+	// Version 5 added IVs, EVs and natures to caught Pokemon. Older ones
+	// have none, which counts as zero IVs and EVs and a neutral nature.
+	4: func(save map[string]json.RawMessage) error {
+		return nil
+	},
+	// Version 6 stopped storing the whole pokemon resource with every
+	// caught Pokemon, naming just its species to fetch it by. Saves
+	// upgraded from before version 4 already do.
+	5: func(save map[string]json.RawMessage) error {
+		var storage struct {
+			Party  []map[string]json.RawMessage   `json:"party"`
+			Boxes  [][]map[string]json.RawMessage `json:"boxes"`
+			NextID int                            `json:"next_id"`
+		}
+		if err := json.Unmarshal(save["bag"], &storage); err != nil {
+			return err
+		}
+
+		caught := slices.Clone(storage.Party)
+		for _, box := range storage.Boxes {
+			caught = append(caught, box...)
+		}
+		for _, p := range caught {
+			raw, ok := p["pokemon"]
+			if !ok {
+				continue
+			}
+			var pokemon struct {
+				Name string `json:"name"`
+			}
+			if err := json.Unmarshal(raw, &pokemon); err != nil {
+				return err
+			}
+			delete(p, "pokemon")
+
+			var err error
+			if p["species"], err = json.Marshal(pokemon.Name); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		save["bag"], err = json.Marshal(storage)
+		return err
+	},
+}
+
+// legacyLevel is the level given to Pokemon from saves that predate
+// levels.
+const legacyLevel = 5
+
+// savePath is where the Pokedex is saved to and loaded from by default.
+// It is empty when the session has no save file.
+var savePath string = defaultSavePath()
+
+// errNoSavePath is returned by save and load without a path in a session
+// that has no save file.
+var errNoSavePath = errors.New("this session has no save file, give a path")
+
+func defaultSavePath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "pokedex.json"
+	}
+	return filepath.Join(dir, "pokedex", "save.json")
+}
+
+func saveGame(path string) error {
+	// Store a seed drawn from the current source rather than the one it
+	// started from, so the next session carries on from here instead of
+	// repeating this one.
+	seed := c.rand.Int63()
+	save := saveFile{
+		Version:   saveFileVersion,
+		Bag:       bag,
+		Seed:      &seed,
+		Inventory: inventory,
+		Money:     money,
+	}
+
+	data, err := json.MarshalIndent(save, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	return writeFileAtomic(path, data, 0o644)
+}
+
+func loadGame(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	data, err = migrateSave(data)
+	if err != nil {
+		return fmt.Errorf("reading save file %s: %w", path, err)
+	}
+
+	var save saveFile
+	if err := json.Unmarshal(data, &save); err != nil {
+		return fmt.Errorf("reading save file %s: %w", path, err)
+	}
+
+	bag = save.Bag
+	if bag == nil {
+		bag = newPokemonStorage()
+	}
+	inventory = save.Inventory
+	if inventory == nil {
+		inventory = map[string]int{}
+	}
+	money = save.Money
+	if save.Seed != nil {
+		c.reseed(*save.Seed)
+	}
+
+	return nil
+}
+
+// migrateSave upgrades an encoded save file of any supported version to
+// saveFileVersion.
+func migrateSave(data []byte) ([]byte, error) {
+	var save map[string]json.RawMessage
+	if err := json.Unmarshal(data, &save); err != nil {
+		return nil, err
+	}
+
+	var version int
+	if err := json.Unmarshal(save["version"], &version); err != nil {
+		return nil, fmt.Errorf("missing version: %w", err)
+	}
+	if version == saveFileVersion {
+		return data, nil
+	}
+
+	for version < saveFileVersion {
+		migrate, ok := saveMigrations[version]
+		if !ok {
+			break
+		}
+		if err := migrate(save); err != nil {
+			return nil, fmt.Errorf("upgrading from version %d: %w", version, err)
+		}
+		version++
+		save["version"], _ = json.Marshal(version)
+	}
+	if version != saveFileVersion {
+		return nil, fmt.Errorf("unsupported version %d", version)
+	}
+
+	return json.Marshal(save)
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames
+// it into place, so a crash mid-write leaves the previous file intact.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName)
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpName, perm); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpName, path)
+}
+
+func commandSave(args commandArgs) error {
+	path := args.arg(0)
+	if path == "" {
+		path = savePath
+	}
+	if path == "" {
+		return errNoSavePath
+	}
+
+	if err := saveGame(path); err != nil {
+		return err
+	}
+
+	fmt.Printf("Saved %d Pokemon to %s\n", bag.len(), path)
+	return nil
+}
+
+func commandLoad(args commandArgs) error {
+	path := args.arg(0)
+	if path == "" {
+		path = savePath
+	}
+	if path == "" {
+		return errNoSavePath
+	}
+
+	err := loadGame(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		fmt.Printf("No save file at %s\n", path)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Loaded %d Pokemon from %s\n", bag.len(), path)
+	return nil
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bytes"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pokedex", "save.json")
+
+	bag = storageOf("pikachu")
+	bag.Party[0].Nickname = "Sparky"
+	inventory, money = map[string]int{"thunder-stone": 1}, 250
+	t.Cleanup(func() { inventory, money = startingInventory(), startingMoney })
+
+	if err := saveGame(path); err != nil {
+		t.Fatalf("saveGame: %v", err)
+	}
+	if data, err := os.ReadFile(path); err != nil || bytes.Contains(data, []byte(`"pokemon"`)) {
+		t.Errorf("expected caught Pokemon to be saved by species, not with the pokemon resource, got %s", data)
+	}
+
+	bag = newPokemonStorage()
+	inventory, money = map[string]int{}, 0
+
+	if err := loadGame(path); err != nil {
+		t.Fatalf("loadGame: %v", err)
+	}
+
+	if pikachu, err := bag.find("pikachu"); err != nil || pikachu.Nickname != "Sparky" {
+		t.Errorf("expected pikachu to be loaded from the save file")
+	}
+	if inventory["thunder-stone"] != 1 || money != 250 {
+		t.Errorf("expected items and money to be loaded from the save file")
+	}
+}
+
+func TestSaveRestoresSeed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+
+	c.reseed(1)
+	if err := saveGame(path); err != nil {
+		t.Fatalf("saveGame: %v", err)
+	}
+
+	c.reseed(2)
+	if err := loadGame(path); err != nil {
+		t.Fatalf("loadGame: %v", err)
+	}
+
+	expected := rand.New(rand.NewSource(1)).Int63()
+	if c.seed != expected {
+		t.Errorf("expected the seed the session left off at, %d, got %d", expected, c.seed)
+	}
+}
+
+func TestLoadMigratesVersion1(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+
+	err := os.WriteFile(path, []byte(`{
+		"version": 1,
+		"bag": {"pikachu": {"name": "pikachu", "weight": 60}},
+		"attempted_catches": {"mewtwo": 2}
+	}`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.reseed(7)
+	inventory, money = map[string]int{}, 0
+	if err := loadGame(path); err != nil {
+		t.Fatalf("loadGame: %v", err)
+	}
+
+	pikachu, err := bag.find("pikachu")
+	if err != nil || pikachu.Species != "pikachu" || pikachu.Level != legacyLevel {
+		t.Errorf("expected a version 1 save to load")
+	}
+	if c.seed != 7 {
+		t.Errorf("expected a save without a seed to keep the session's, got %d", c.seed)
+	}
+	if inventory["poke-ball"] != startingBalls || money != startingMoney {
+		t.Errorf("expected an old save to start with a new player's items, got %v and %d", inventory, money)
+	}
+}
+
+func TestLoadMigratesVersion5(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+
+	err := os.WriteFile(path, []byte(`{
+		"version": 5,
+		"bag": {
+			"party": [{"id": 1, "level": 12, "pokemon": {"name": "pikachu", "weight": 60}, "nature": "timid"}],
+			"boxes": [[{"id": 2, "nickname": "Gale", "level": 3, "pokemon": {"name": "pidgey"}}]],
+			"next_id": 3
+		},
+		"inventory": {},
+		"money": 0
+	}`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := loadGame(path); err != nil {
+		t.Fatalf("loadGame: %v", err)
+	}
+
+	pikachu, err := bag.find("pikachu")
+	if err != nil || pikachu.Species != "pikachu" || pikachu.Level != 12 || pikachu.Nature != "timid" {
+		t.Errorf("expected pikachu to keep everything but the pokemon resource, got %+v, %v", pikachu, err)
+	}
+	if gale, err := bag.find("gale"); err != nil || gale.Species != "pidgey" {
+		t.Errorf("expected boxed Pokemon to be upgraded too, got %+v, %v", gale, err)
+	}
+	if bag.NextID != 3 {
+		t.Errorf("expected the next ID to be kept, got %d", bag.NextID)
+	}
+}
+
+func TestLoadUnsupportedVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+
+	err := os.WriteFile(path, []byte(`{"version": 999, "bag": {}}`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := loadGame(path); err == nil {
+		t.Errorf("expected an error for an unsupported save version")
+	}
+}
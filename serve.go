@@ -0,0 +1,33 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/chandanbsd/pokedex/internal/fixture"
+)
+
+// runServe implements "pokedex serve", which serves recorded PokeAPI
+// responses so the REPL can be pointed at it with -api-url.
+func runServe(args []string) error {
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := flags.String("addr", "localhost:8080", "address to listen on")
+	dir := flags.String("dir", "fixtures/pokeapi", "directory of recorded PokeAPI JSON")
+	flags.Parse(args)
+
+	if _, err := os.Stat(*dir); err != nil {
+		return err
+	}
+
+	fmt.Printf("Serving %s at http://%s%s\n", *dir, *addr, fixture.PathPrefix)
+	return http.ListenAndServe(*addr, fixture.NewHandler(*dir))
+}
+
+func envOr(key, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		return value
+	}
+	return fallback
+}
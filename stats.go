@@ -0,0 +1,116 @@
+package main
+
+import (
+	"math/rand"
+
+	"github.com/chandanbsd/pokedex/internal/model"
+)
+
+// statNames are the six stats, in the order PokeAPI lists them.
+var statNames = []string{"hp", "attack", "defense", "special-attack", "special-defense", "speed"}
+
+const (
+	maxIV      = 31
+	maxStatEV  = 252
+	maxTotalEV = 510
+)
+
+// nature raises one stat by a tenth and lowers another by as much. The
+// five natures that raise and lower the same stat have no effect.
+type nature struct {
+	name      string
+	increased string
+	decreased string
+}
+
+// natures are in their index order from the games, which is what makes
+// the stat they raise and lower follow from their position.
+var natures = func() []nature {
+	names := []string{
+		"hardy", "lonely", "brave", "adamant", "naughty",
+		"bold", "docile", "relaxed", "impish", "lax",
+		"timid", "hasty", "serious", "jolly", "naive",
+		"modest", "mild", "quiet", "bashful", "rash",
+		"calm", "gentle", "sassy", "careful", "quirky",
+	}
+	affected := []string{"attack", "defense", "speed", "special-attack", "special-defense"}
+
+	natures := make([]nature, len(names))
+	for i, name := range names {
+		natures[i] = nature{name: name, increased: affected[i/5], decreased: affected[i%5]}
+	}
+	return natures
+}()
+
+// natureNamed looks up a nature by name. Unknown names, such as the empty
+// one, are treated as a neutral nature.
+func natureNamed(name string) nature {
+	for _, n := range natures {
+		if n.name == name {
+			return n
+		}
+	}
+	return natures[0]
+}
+
+// modifier is the percentage the nature scales the named stat by.
+func (n nature) modifier(stat string) int {
+	switch {
+	case n.increased == n.decreased:
+		return 100
+	case stat == n.increased:
+		return 110
+	case stat == n.decreased:
+		return 90
+	}
+	return 100
+}
+
+func rollIVs(rng *rand.Rand) map[string]int {
+	ivs := make(map[string]int, len(statNames))
+	for _, stat := range statNames {
+		ivs[stat] = rng.Intn(maxIV + 1)
+	}
+	return ivs
+}
+
+func rollNature(rng *rand.Rand) string {
+	return natures[rng.Intn(len(natures))].name
+}
+
+// gainEVs adds the effort values the species of defeated yields, as the
+// games do after a battle, up to the limits per stat and in total.
+func (p *caughtPokemon) gainEVs(defeated model.Pokemon) {
+	if p.EVs == nil {
+		p.EVs = map[string]int{}
+	}
+
+	total := 0
+	for _, ev := range p.EVs {
+		total += ev
+	}
+
+	for _, stat := range defeated.Stats {
+		gain := min(stat.Effort, maxStatEV-p.EVs[stat.Stat.Name], maxTotalEV-total)
+		if gain <= 0 {
+			continue
+		}
+		p.EVs[stat.Stat.Name] += gain
+		total += gain
+	}
+}
+
+// statAt computes the named stat at the given level from its base stat and
+// the Pokemon's IV, EV and nature, with the formula of generation III on.
+func (p *caughtPokemon) statAt(stat model.PokemonStat, level int) int {
+	name := stat.Stat.Name
+	return calcStat(name, stat.BaseStat, p.IVs[name], p.EVs[name], level, natureNamed(p.Nature))
+}
+
+func calcStat(name string, base, iv, ev, level int, n nature) int {
+	value := (2*base + iv + ev/4) * level / 100
+	if name == "hp" {
+		return value + level + 10
+	}
+	return (value + 5) * n.modifier(name) / 100
+}
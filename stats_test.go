@@ -0,0 +1,89 @@
+package main
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/chandanbsd/pokedex/internal/model"
+)
+
+func pokemonWithStats(base, effort []int) model.Pokemon {
+	var pokemon model.Pokemon
+	for i, name := range statNames {
+		stat := model.PokemonStat{BaseStat: base[i], Effort: effort[i]}
+		stat.Stat.Name = name
+		pokemon.Stats = append(pokemon.Stats, stat)
+	}
+	return pokemon
+}
+
+func TestStatAt(t *testing.T) {
+	// Bulbapedia's worked example: a level 78 Adamant Garchomp.
+	species := pokemonWithStats([]int{108, 130, 95, 80, 85, 102}, make([]int, 6))
+	garchomp := &caughtPokemon{
+		Level:  78,
+		Nature: "adamant",
+		IVs:    map[string]int{"hp": 24, "attack": 12, "defense": 30, "special-attack": 16, "special-defense": 23, "speed": 5},
+		EVs:    map[string]int{"hp": 74, "attack": 190, "defense": 91, "special-attack": 48, "special-defense": 84, "speed": 23},
+	}
+	expected := []int{289, 278, 193, 135, 171, 171}
+
+	for i, stat := range species.Stats {
+		if actual := garchomp.statAt(stat, garchomp.Level); actual != expected[i] {
+			t.Errorf("%s: expected %d, got %d", stat.Stat.Name, expected[i], actual)
+		}
+	}
+}
+
+func TestNatures(t *testing.T) {
+	cases := []struct {
+		name      string
+		increased string
+		decreased string
+	}{
+		{name: "adamant", increased: "attack", decreased: "special-attack"},
+		{name: "timid", increased: "speed", decreased: "attack"},
+		{name: "calm", increased: "special-defense", decreased: "attack"},
+		{name: "relaxed", increased: "defense", decreased: "speed"},
+	}
+
+	for _, c := range cases {
+		n := natureNamed(c.name)
+		if n.name != c.name || n.modifier(c.increased) != 110 || n.modifier(c.decreased) != 90 {
+			t.Errorf("expected %s to raise %s and lower %s, got %+v", c.name, c.increased, c.decreased, n)
+		}
+	}
+
+	for _, name := range []string{"hardy", "serious", ""} {
+		for _, stat := range statNames {
+			if natureNamed(name).modifier(stat) != 100 {
+				t.Errorf("expected %q to leave %s alone", name, stat)
+			}
+		}
+	}
+}
+
+func TestGainEVs(t *testing.T) {
+	p := &caughtPokemon{}
+	tentacruel := pokemonWithStats(make([]int, 6), []int{0, 0, 0, 0, 2, 0})
+	mewtwo := pokemonWithStats(make([]int, 6), []int{0, 0, 0, 3, 0, 0})
+	pidgey := pokemonWithStats(make([]int, 6), []int{0, 0, 0, 0, 0, 1})
+
+	for i := 0; i < 200; i++ {
+		p.gainEVs(tentacruel)
+	}
+	if p.EVs["special-defense"] != maxStatEV {
+		t.Errorf("expected EVs to stop at %d, got %d", maxStatEV, p.EVs["special-defense"])
+	}
+
+	for i := 0; i < 200; i++ {
+		p.gainEVs(mewtwo)
+	}
+	for i := 0; i < 200; i++ {
+		p.gainEVs(pidgey)
+	}
+	expected := map[string]int{"special-defense": maxStatEV, "special-attack": maxStatEV, "speed": maxTotalEV - 2*maxStatEV}
+	if !maps.Equal(p.EVs, expected) {
+		t.Errorf("expected EVs to stop at %d in total, got %v", maxTotalEV, p.EVs)
+	}
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+const prompt = "Pokedex > "
+
+// historySize bounds how many lines are kept in the history file.
+const historySize = 1000
+
+// lineReader yields lines of input until io.EOF.
+type lineReader interface {
+	ReadLine() (string, error)
+}
+
+// scannerReader reads lines from a plain reader, printing prompt before
+// each one if set.
+type scannerReader struct {
+	scanner *bufio.Scanner
+	prompt  string
+}
+
+func (s scannerReader) ReadLine() (string, error) {
+	if s.prompt != "" {
+		fmt.Print(s.prompt)
+	}
+
+	if !s.scanner.Scan() {
+		if s.prompt != "" {
+			fmt.Println()
+		}
+		if err := s.scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", io.EOF
+	}
+	return s.scanner.Text(), nil
+}
+
+// terminalReader reads lines from a terminal with line editing, history
+// and tab completion. The terminal is only in raw mode while a line is
+// being read, so commands can print normally.
+type terminalReader struct {
+	fd       int
+	terminal *term.Terminal
+}
+
+func newTerminalReader(in, out *os.File) *terminalReader {
+	terminal := term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{in, out}, prompt)
+	terminal.History = loadHistory(historyPath(), historySize)
+	terminal.AutoCompleteCallback = complete
+
+	return &terminalReader{fd: int(in.Fd()), terminal: terminal}
+}
+
+func (t *terminalReader) ReadLine() (string, error) {
+	state, err := term.MakeRaw(t.fd)
+	if err != nil {
+		return "", err
+	}
+	defer term.Restore(t.fd, state)
+
+	line, err := t.terminal.ReadLine()
+	if err == io.EOF {
+		t.terminal.Write([]byte("\r\n"))
+	}
+	return line, err
+}
+
+func historyPath() string {
+	return filepath.Join(filepath.Dir(defaultSavePath()), "history")
+}
+
+// historyFile is a term.History that also appends every entry to a file,
+// so history carries over between sessions.
+type historyFile struct {
+	path string
+	max  int
+	// lines holds the history oldest first.
+	lines []string
+}
+
+// loadHistory reads up to max lines of history from path. A missing or
+// unreadable file starts an empty history.
+func loadHistory(path string, max int) *historyFile {
+	h := &historyFile{path: path, max: max}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return h
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		if line != "" {
+			h.lines = append(h.lines, line)
+		}
+	}
+
+	if len(h.lines) > max {
+		h.lines = h.lines[len(h.lines)-max:]
+		writeFileAtomic(path, []byte(strings.Join(h.lines, "\n")+"\n"), 0o600)
+	}
+
+	return h
+}
+
+func (h *historyFile) Add(entry string) {
+	if strings.TrimSpace(entry) == "" {
+		return
+	}
+	if len(h.lines) > 0 && h.lines[len(h.lines)-1] == entry {
+		return
+	}
+
+	h.lines = append(h.lines, entry)
+	if len(h.lines) > h.max {
+		h.lines = h.lines[1:]
+	}
+
+	if err := os.MkdirAll(filepath.Dir(h.path), 0o755); err != nil {
+		return
+	}
+	f, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	fmt.Fprintln(f, entry)
+}
+
+func (h *historyFile) Len() int {
+	return len(h.lines)
+}
+
+func (h *historyFile) At(idx int) string {
+	return h.lines[len(h.lines)-1-idx]
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/chandanbsd/pokedex/internal/model"
+	"github.com/chandanbsd/pokedex/internal/pokeapi"
+)
+
+// commandWhere lists the location areas a Pokemon can be found in,
+// grouped by game version.
+func commandWhere(args commandArgs) error {
+	pokemonName := args.arg(0)
+
+	filter, err := parseEncounterFilter(args)
+	if err != nil {
+		return err
+	}
+
+	pokemon, err := client.GetPokemon(pokemonName)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return fmt.Errorf("no Pokemon named %s", pokemonName)
+	}
+	if err != nil {
+		return apiError(err)
+	}
+
+	encounters, err := pokeapi.Fetch[[]model.LocationAreaEncounter](client, pokemon.LocationAreaEncounters)
+	if err != nil {
+		return apiError(err)
+	}
+
+	var rows []encounterRow
+	for _, encounter := range encounters {
+		rows = appendEncounterRows(rows, pokemonName, encounter.LocationArea.Name, encounter.VersionDetails, filter)
+	}
+	if len(rows) == 0 {
+		fmt.Printf("%s cannot be found in the wild\n", pokemonName)
+		return nil
+	}
+
+	for _, row := range rows {
+		seenLocationAreas[row.area] = true
+	}
+	printWhere(os.Stdout, rows)
+	return nil
+}
+
+// printWhere prints rows grouped by version, in the order the versions
+// first appear.
+func printWhere(w io.Writer, rows []encounterRow) {
+	var versions []string
+	byVersion := map[string][]encounterRow{}
+	for _, row := range rows {
+		if _, ok := byVersion[row.version]; !ok {
+			versions = append(versions, row.version)
+		}
+		byVersion[row.version] = append(byVersion[row.version], row)
+	}
+
+	for _, version := range versions {
+		fmt.Fprintf(w, "%s:\n", version)
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		for _, row := range byVersion[version] {
+			fmt.Fprintf(tw, "  %s\t%s\t%d%%\tlevel %s\n", row.area, row.method, row.chance, row.levels())
+		}
+		tw.Flush()
+	}
+}
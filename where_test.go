@@ -0,0 +1,46 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPrintWhere(t *testing.T) {
+	rows := []encounterRow{
+		{area: "viridian-forest-area", version: "red", method: "walk", chance: 5, minLevel: 3, maxLevel: 5},
+		{area: "kanto-route-1-area", version: "yellow", method: "walk", chance: 35, minLevel: 2, maxLevel: 2},
+		{area: "viridian-forest-area", version: "yellow", method: "walk", chance: 10, minLevel: 3, maxLevel: 5},
+	}
+
+	var out strings.Builder
+	printWhere(&out, rows)
+
+	expected := `red:
+  viridian-forest-area  walk  5%  level 3-5
+yellow:
+  kanto-route-1-area    walk  35%  level 2
+  viridian-forest-area  walk  10%  level 3-5
+`
+	if out.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, out.String())
+	}
+}
+
+func TestWhere(t *testing.T) {
+	useCassette(t, "testdata/session.json")
+	seenLocationAreas = map[string]bool{}
+
+	if err := runCommand("where magikarp --version=diamond"); err != nil {
+		t.Fatalf("where: %v", err)
+	}
+	if !seenLocationAreas["canalave-city-area"] {
+		t.Errorf("expected the areas found to be offered by tab completion")
+	}
+
+	if err := runCommand("where eevee"); err != nil {
+		t.Errorf("where: %v", err)
+	}
+	if err := runCommand("where pikachuu"); err == nil || err.Error() != "no Pokemon named pikachuu" {
+		t.Errorf("expected a friendly error for a missing Pokemon, got %v", err)
+	}
+}